@@ -4,45 +4,95 @@
 package analyzer
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
-	"strings"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"unicode/utf8"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
 )
 
-// Analyzer implements the check.
-var Analyzer = newAnalyzer()
+// Analyzer implements the check using DefaultConfig, adjustable through its flags.
+var Analyzer = NewAnalyzer(DefaultConfig())
 
-func newAnalyzer() *analysis.Analyzer {
+// NewAnalyzer returns an Analyzer that checks doc comments according to cfg.
+// Each Analyzer owns a private copy of its configuration, which its flags
+// update, so differently configured instances can run side by side.
+//
+// Settings from a .docnametypo.yaml, .docnametypo.yml or .docnametypo.json
+// file, found by walking up from each package directory to the module root,
+// override cfg; flags set explicitly on the Analyzer override the file.
+func NewAnalyzer(cfg Config) *analysis.Analyzer {
+	base := cfg
+	c := &cfg
+	configs := newConfigLoader()
+	baselines := newBaselineStore()
+	diffs := newDiffStore()
+	histories := newHistoryStore()
+	explicit := &explicitFlags{}
 	a := &analysis.Analyzer{
-		Name:     "docnametypo",
-		Doc:      "flag doc comments that start with an identifier very similar to the symbol's name (probable typo/stale)",
-		Run:      run,
-		Requires: []*analysis.Analyzer{inspect.Analyzer},
-	}
-
-	a.Flags.IntVar(&maxDistFlag, "maxdist", maxDistFlag, "maximum Damerau-Levenshtein distance to consider a likely typo")
-	a.Flags.BoolVar(&includeUnexportedFlag, "include-unexported", includeUnexportedFlag, "check unexported declarations")
-	a.Flags.BoolVar(&includeExportedFlag, "include-exported", includeExportedFlag, "check exported declarations (disabled by default)")
-	a.Flags.BoolVar(&includeTypesFlag, "include-types", includeTypesFlag, "also check type declarations")
-	a.Flags.BoolVar(&includeGeneratedFlag, "include-generated", includeGeneratedFlag, "check files marked as generated")
-	a.Flags.BoolVar(&includeInterfaceMethodsFlag, "include-interface-methods", includeInterfaceMethodsFlag, "check interface method declarations")
-	a.Flags.StringVar(&allowedLeadingWordsFlag, "allowed-leading-words", allowedLeadingWordsFlag, "comma-separated list of leading words to ignore (treated as narrative)")
-	a.Flags.StringVar(&allowedPrefixesFlag, "allowed-prefixes", allowedPrefixesFlag, "comma-separated list of symbol prefixes to ignore when matching doc tokens")
-	a.Flags.BoolVar(&skipPlainWordCamelFlag, "skip-plain-word-camel", skipPlainWordCamelFlag, "skip plain leading words when the symbol looks camelCase (reduces narrative false positives)")
-	a.Flags.IntVar(&maxCamelChunkInsertFlag, "max-camel-chunk-insert", maxCamelChunkInsertFlag, "maximum number of camelCase chunks that may be inserted or removed (detects missing words)")
-	a.Flags.IntVar(&maxCamelChunkReplaceFlag, "max-camel-chunk-replace", maxCamelChunkReplaceFlag, "maximum number of camelCase chunks that may be replaced (detects word changes)")
-
+		Name:       "docnametypo",
+		Doc:        "flag doc comments that start with an identifier very similar to the symbol's name (probable typo/stale)",
+		Requires:   []*analysis.Analyzer{inspect.Analyzer},
+		ResultType: reflect.TypeFor[*Result](),
+	}
+	a.Run = func(pass *analysis.Pass) (any, error) {
+		conf, err := passConfig(pass, base, *c, &a.Flags, explicit, configs)
+		if err != nil {
+			return nil, err
+		}
+		return run(pass, conf, baselines, diffs, histories)
+	}
+	registerFlags(&a.Flags, c)
+	explicit.track(&a.Flags)
 	return a
 }
 
-func run(pass *analysis.Pass) (any, error) {
-	cfg := newMatchConfig()
+// checker holds the per-pass state shared by the declaration checks.
+type checker struct {
+	pass    *analysis.Pass
+	cfg     matchConfig
+	decls   declIndex
+	ignores *ignoreSet
+	// baseline is set when findings are filtered through or recorded into a
+	// baseline file.
+	baseline *passBaseline
+	// trace selects the declarations whose evaluation is traced.
+	trace    *regexp.Regexp
+	traceOut io.Writer
+	// history supplies earlier versions of files for GitHistory.
+	history *historyStore
+	// changes limits findings to changed lines; nil keeps all of them.
+	changes changedLines
+	// renames is built when a finding first needs a rename fix.
+	renames *renameScope
+	// result collects the confidence of each reported finding.
+	result *Result
+}
+
+func run(pass *analysis.Pass, conf Config, baselines *baselineStore, diffs *diffStore, histories *historyStore) (any, error) {
+	switch conf.Distance {
+	case "", distanceDamerau, distanceWeighted:
+	default:
+		return nil, fmt.Errorf("docnametypo -distance: unknown metric %q (want %s or %s)", conf.Distance, distanceDamerau, distanceWeighted)
+	}
+	if conf.MinConfidence < 0 || conf.MinConfidence > 1 {
+		return nil, fmt.Errorf("docnametypo -min-confidence: %g is not between 0 and 1", conf.MinConfidence)
+	}
+	cfg := newMatchConfig(conf)
 
 	tokenToAST := make(map[*token.File]*ast.File, len(pass.Files))
+	checked := make([]*ast.File, 0, len(pass.Files))
 	for _, f := range pass.Files {
 		if f == nil {
 			continue
@@ -50,13 +100,42 @@ func run(pass *analysis.Pass) (any, error) {
 		if tf := pass.Fset.File(f.Pos()); tf != nil {
 			tokenToAST[tf] = f
 		}
+		if cfg.IncludeGenerated || !ast.IsGenerated(f) {
+			checked = append(checked, f)
+		}
+	}
+
+	c := &checker{
+		pass:    pass,
+		cfg:     cfg,
+		decls:   newDeclIndex(pass.Files),
+		ignores: newIgnoreSet(pass.Fset, checked),
+		history: histories,
+		result:  &Result{confidence: make(map[resultKey]float64)},
+	}
+	baseline, err := newPassBaseline(pass, conf, baselines)
+	if err != nil {
+		return nil, err
+	}
+	c.baseline = baseline
+	if c.changes, err = diffs.changes(pass, conf); err != nil {
+		return nil, err
+	}
+	if conf.Trace != "" {
+		if c.trace, err = regexp.Compile(conf.Trace); err != nil {
+			return nil, fmt.Errorf("docnametypo -trace-symbols: %w", err)
+		}
+		c.traceOut = conf.TraceOutput
+		if c.traceOut == nil {
+			c.traceOut = os.Stderr
+		}
 	}
 
 	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
-	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil), (*ast.GenDecl)(nil)}
+	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil), (*ast.GenDecl)(nil), (*ast.StructType)(nil)}
 
 	ins.Preorder(nodeFilter, func(n ast.Node) {
-		if !includeGeneratedFlag {
+		if !cfg.IncludeGenerated {
 			if tf := pass.Fset.File(n.Pos()); tf != nil {
 				if af, ok := tokenToAST[tf]; ok && ast.IsGenerated(af) {
 					return
@@ -66,12 +145,35 @@ func run(pass *analysis.Pass) (any, error) {
 
 		switch node := n.(type) {
 		case *ast.FuncDecl:
-			if node.Doc == nil || node.Name == nil {
+			if node.Name == nil {
+				return
+			}
+			if cfg.CheckTestNames {
+				c.checkTestName(node)
+			}
+			if node.Doc == nil {
 				return
 			}
-			checkSymbol(pass, cfg, node.Doc, node.Name.Name, ast.IsExported(node.Name.Name), kindFunc, node.Name.Pos())
+			if node.Recv != nil && len(node.Recv.List) > 0 {
+				if q, ok := qualifiedMethodName(node.Doc); ok {
+					c.checkQualifiedMethod(node, q)
+					return
+				}
+			}
+			c.checkSymbol(node.Doc, node.Name.Name, ast.IsExported(node.Name.Name), kindFunc, node.Name.Pos())
+
+		case *ast.StructType:
+			if cfg.IncludeFields {
+				c.checkStructFields(node)
+			}
 
 		case *ast.GenDecl:
+			if node.Tok == token.CONST || node.Tok == token.VAR {
+				if cfg.IncludeValues {
+					c.checkValueSpecs(node)
+				}
+				return
+			}
 			if node.Tok != token.TYPE {
 				return
 			}
@@ -81,26 +183,44 @@ func run(pass *analysis.Pass) (any, error) {
 					continue
 				}
 
-				if includeTypesFlag {
+				if cfg.IncludeTypes {
 					doc := ts.Doc
 					if doc == nil {
 						doc = node.Doc
 					}
 					if doc != nil {
-						checkSymbol(pass, cfg, doc, ts.Name.Name, ast.IsExported(ts.Name.Name), kindType, ts.Name.Pos())
+						c.checkSymbol(doc, ts.Name.Name, ast.IsExported(ts.Name.Name), kindType, ts.Name.Pos())
 					}
 				}
 
-				if includeInterfaceMethodsFlag {
+				if cfg.IncludeInterfaceMethods {
 					if iface, ok := ts.Type.(*ast.InterfaceType); ok {
-						checkInterfaceMethods(pass, cfg, iface)
+						c.checkInterfaceMethods(iface)
 					}
 				}
 			}
 		}
 	})
 
-	return nil, nil
+	if cfg.IncludePackageDoc {
+		for _, f := range checked {
+			c.checkPackageDoc(f)
+		}
+	}
+
+	if cfg.CheckDocLinks {
+		for _, f := range checked {
+			c.checkDocLinks(f)
+		}
+	}
+
+	if cfg.ReportUnusedIgnores {
+		c.ignores.reportUnused(pass, c.changes)
+	}
+	if c.baseline != nil {
+		c.baseline.finish(pass, c.result)
+	}
+	return c.result, nil
 }
 
 type symbolKind int
@@ -108,106 +228,253 @@ type symbolKind int
 const (
 	kindFunc symbolKind = iota
 	kindType
+	kindValue
+	kindField
+	kindPackage
 )
 
+// String returns the name used for the kind in baseline files.
+func (k symbolKind) String() string {
+	switch k {
+	case kindFunc:
+		return "func"
+	case kindType:
+		return "type"
+	case kindValue:
+		return "value"
+	case kindField:
+		return "field"
+	case kindPackage:
+		return "package"
+	}
+	return "unknown"
+}
+
+// finding describes a doc comment whose first token does not match the
+// declaration it documents.
+type finding struct {
+	doc      *ast.CommentGroup
+	name     string
+	kind     symbolKind
+	docTok   string
+	tokStart token.Pos
+	tokEnd   token.Pos
+	declPos  token.Pos
+	trace    *declTrace
+	// confidence is how likely the finding is real, from 0 to 1.
+	confidence float64
+}
+
 // checkSymbol compares the comment token against the provided symbol.
-func checkSymbol(pass *analysis.Pass, cfg matchConfig, doc *ast.CommentGroup, name string, exported bool, kind symbolKind, declPos token.Pos) {
+func (c *checker) checkSymbol(doc *ast.CommentGroup, name string, exported bool, kind symbolKind, declPos token.Pos) {
 	if name == "" || doc == nil {
 		return
 	}
+	cfg := c.cfg
+	tr := c.startTrace(name, kind, declPos)
+	defer tr.flush()
 
 	if exported {
-		if !includeExportedFlag {
+		if !cfg.IncludeExported {
+			tr.step("skipped: exported declarations are not checked")
 			return
 		}
-	} else if !includeUnexportedFlag {
+	} else if !cfg.IncludeUnexported {
+		tr.step("skipped: unexported declarations are not checked")
 		return
 	}
 
 	firstTok, tokStart, tokEnd, docLine := firstIdentifierLike(doc)
-	if firstTok == "" || len(firstTok) < minDocTokenLen {
+	tr.step("doc line: %q", docLine)
+	tr.step("doc token: %q", firstTok)
+	if firstTok == "" || utf8.RuneCountInString(firstTok) < minDocTokenLen {
+		tr.step("skipped: no doc token of at least %d characters", minDocTokenLen)
 		return
 	}
 
-	if docFirstWordHasDot(docLine) {
-		return
-	}
-	if cfg.isAllowedLeadingWord(firstTok) {
-		return
-	}
-	if cfg.matchesAllowedPrefixVariant(firstTok, name) {
-		return
+	f := finding{doc: doc, name: name, kind: kind, docTok: firstTok, tokStart: tokStart, tokEnd: tokEnd, declPos: declPos, trace: tr}
+	skips := []struct {
+		rule string
+		skip func() bool
+	}{
+		{"docFirstWordHasDot", func() bool { return docFirstWordHasDot(docLine) }},
+		{"isAllowedLeadingWord", func() bool { return cfg.isAllowedLeadingWord(firstTok) }},
+		{"matchesAllowedPrefixVariant", func() bool { return cfg.matchesAllowedPrefixVariant(firstTok, name) }},
+		{"isSectionHeader", func() bool { return isSectionHeader(firstTok, docLine) }},
+		{"isNarrativeSentenceIntro", func() bool { return isNarrativeSentenceIntro(firstTok, docLine) }},
+		{"containsWildcardToken", func() bool { return containsWildcardToken(firstTok, docLine) }},
+		{"isNarrativeVerbForm", func() bool { return kind == kindFunc && isNarrativeVerbForm(firstTok, name) }},
+		{"skipPlainWordCamel", func() bool {
+			return cfg.SkipPlainWordCamel && looksLikeSimpleWord(firstTok) && hasCamelCaseInterior(name)
+		}},
 	}
-	if isSectionHeader(firstTok, docLine) {
-		return
+	for _, r := range skips {
+		if r.skip() {
+			tr.step("%s: yes", r.rule)
+			tr.step("skipped by %s", r.rule)
+			c.checkHistory(f)
+			return
+		}
+		tr.step("%s: no", r.rule)
 	}
-	if isNarrativeSentenceIntro(firstTok, docLine) {
+
+	if other, ok := c.decls.lookup(declPos, firstTok); ok && firstTok != name && !looksLikeSimpleWord(firstTok) {
+		tr.step("matched by %s: doc token names the declaration at %s", ruleCopyPaste, c.pass.Fset.Position(other.Pos()))
+		c.reportOtherDecl(f, other)
 		return
 	}
-	if containsWildcardToken(firstTok, docLine) {
+
+	m, ok := matchDocToken(cfg, firstTok, name)
+	if !ok {
+		tr.step("not reported: no heuristic matched")
+		c.checkHistory(f)
 		return
 	}
-	if kind == kindFunc && isNarrativeVerbForm(firstTok, name) {
-		return
+	tr.step("matched by %s", m)
+
+	msg := "doc comment starts with '" + firstTok + "' but symbol is '" + name + "' (" + m.reason() + ")"
+	if cfg.Explain {
+		msg += " [" + m.String() + "]"
 	}
-	if skipPlainWordCamelFlag && looksLikeSimpleWord(firstTok) && hasCamelCaseInterior(name) {
-		return
+	fixes := replaceTokenFixes(doc, firstTok, tokStart, tokEnd, name)
+	if !m.isSpelling() {
+		fixes = append(fixes, c.renameFix(name, firstTok, declPos)...)
 	}
+	f.confidence = m.confidence
+	c.report(f, analysis.Diagnostic{
+		Pos:            declPos,
+		Category:       m.rule,
+		Message:        msg,
+		SuggestedFixes: fixes,
+	})
+}
 
-	lenDiff := abs(len(firstTok) - len(name))
-	var docLower, nameLower string
-	match := false
-	if lenDiff <= maxDistFlag+1 || lenDiff <= maxChunkDiffSize {
-		docLower = strings.ToLower(firstTok)
-		nameLower = strings.ToLower(name)
-		d := damerauLevenshtein(docLower, nameLower)
-		match = d > 0 && d <= maxDistFlag
-		if match && !passesDistanceGate(docLower, nameLower, d) {
-			match = false
-		}
+// reportOtherDecl flags a doc token that names a different declaration in the
+// package, which usually means the comment was copied from it. The fix only
+// rewrites the doc token, since the rest of the comment may mention the
+// other declaration on purpose, as in "movePods is like copyPods but ...".
+func (c *checker) reportOtherDecl(f finding, other *ast.Ident) {
+	where := c.pass.Fset.Position(other.Pos())
+	loc := filepath.Base(where.Filename) + ":" + strconv.Itoa(where.Line)
+	msg := "doc comment describes '" + f.docTok + "', which is declared at " + loc + ", but symbol is '" + f.name + "' (possible copy-paste)"
+	if c.cfg.Explain {
+		msg += " [" + ruleCopyPaste + "]"
 	}
+	f.confidence = copyPasteConfidence
+	c.report(f, analysis.Diagnostic{
+		Pos:            f.declPos,
+		Category:       ruleCopyPaste,
+		Message:        msg,
+		SuggestedFixes: replaceTokenFixes(nil, f.docTok, f.tokStart, f.tokEnd, f.name),
+		Related: []analysis.RelatedInformation{{
+			Pos:     other.Pos(),
+			End:     other.End(),
+			Message: "'" + f.docTok + "' is declared here",
+		}},
+	})
+}
 
-	if !match && isCamelSwapVariant(firstTok, name) {
-		match = true
+// report emits d unless an ignore directive covers the doc comment or the
+// declaration line, neither of them was changed in diff-only mode, the
+// finding is recorded in the baseline, or its confidence is below
+// MinConfidence. Diagnostics cover the doc token and point at the
+// declaration as related information, unless ReportAtDeclaration asks for
+// the legacy position. The confidence is recorded in the pass's Result, and
+// appended to the message with Explain.
+func (c *checker) report(f finding, d analysis.Diagnostic) {
+	if c.ignores.suppresses(f.doc, f.declPos) {
+		f.trace.step("not reported: suppressed by an ignore directive")
+		return
 	}
-	if !match && strings.EqualFold(firstTok, name) && firstTok != name {
-		match = true
+	// The baseline is checked before the changed lines so that entries for
+	// findings on unchanged lines are not reported as stale.
+	if c.baseline != nil && c.baseline.suppresses(c.baselineEntry(f)) {
+		f.trace.step("not reported: covered by the baseline")
+		return
 	}
-	if !match && hasSimilarCamelWord(firstTok, name) {
-		match = true
+	docChanged := f.doc != nil && c.changes.covers(c.pass.Fset, f.doc.Pos(), f.doc.End())
+	if !docChanged && !c.changes.covers(c.pass.Fset, f.declPos, token.NoPos) {
+		f.trace.step("not reported: outside the changed lines")
+		return
 	}
-	if !match && hasCamelChunkReplacement(firstTok, name, maxCamelChunkReplaceFlag) {
-		match = true
+	// Findings below the threshold still use ignore directives and baseline
+	// entries, so changing -min-confidence does not make those stale.
+	if f.confidence < c.cfg.MinConfidence {
+		f.trace.step("not reported: confidence %s is below -min-confidence", formatConfidence(f.confidence))
+		return
 	}
-	if !match && hasCamelChunkInsertionOrRemoval(firstTok, name, maxCamelChunkInsertFlag) {
-		match = true
+	if c.cfg.Explain {
+		d.Message += " (confidence " + formatConfidence(f.confidence) + ")"
 	}
-	if !match && nameLower != "" && docLower != "" && hasSmallChunkDifference(docLower, nameLower, maxChunkDiffSize) {
-		match = true
+	if !c.cfg.ReportAtDeclaration && f.tokStart.IsValid() && f.tokStart < f.tokEnd {
+		d.Pos, d.End = f.tokStart, f.tokEnd
+		d.Related = append([]analysis.RelatedInformation{{
+			Pos:     f.declPos,
+			End:     f.declPos + token.Pos(len(f.name)),
+			Message: "'" + f.name + "' is declared here",
+		}}, d.Related...)
 	}
+	f.trace.step("reported")
+	c.result.confidence[resultKey{d.Pos, d.End, d.Message}] = f.confidence
+	c.pass.Report(d)
+}
 
-	if !match {
-		return
-	}
+// formatConfidence formats a confidence with the two decimals it is
+// rounded to.
+func formatConfidence(c float64) string {
+	return strconv.FormatFloat(c, 'f', 2, 64)
+}
+
+// Result is the analyzer's result for a package. It holds the confidence of
+// each finding the pass reported, and with BaselineWrite, the findings to
+// pass to WriteBaseline.
+type Result struct {
+	confidence map[resultKey]float64
+	// baselinePath and baselineEntries are the BaselineWrite file and the
+	// findings recorded for it.
+	baselinePath    string
+	baselineEntries []baselineEntry
+}
+
+// resultKey identifies a diagnostic reported by a pass.
+type resultKey struct {
+	pos, end token.Pos
+	message  string
+}
 
-	msg := "doc comment starts with '" + firstTok + "' but symbol is '" + name + "' (possible typo or old name)"
-	var fixes []analysis.SuggestedFix
-	if tokStart.IsValid() && tokEnd.IsValid() && tokStart < tokEnd {
-		fixes = []analysis.SuggestedFix{{
-			Message:   "replace doc token with symbol name",
-			TextEdits: []analysis.TextEdit{{Pos: tokStart, End: tokEnd, NewText: []byte(name)}},
-		}}
+// Confidence returns the confidence, from 0 to 1, of a diagnostic reported
+// by the pass that returned r. Stale baseline entries and unused ignore
+// directives have none.
+func (r *Result) Confidence(d analysis.Diagnostic) (float64, bool) {
+	if r == nil {
+		return 0, false
 	}
+	c, ok := r.confidence[resultKey{d.Pos, d.End, d.Message}]
+	return c, ok
+}
 
-	pass.Report(analysis.Diagnostic{
-		Pos:            declPos,
-		Message:        msg,
-		SuggestedFixes: fixes,
-	})
+// replaceTokenFixes rewrites the doc token to the symbol name, along with any
+// later whole-word occurrences of it in doc, such as [docTok] doc links or
+// `docTok` code spans. A nil doc rewrites the doc token alone.
+func replaceTokenFixes(doc *ast.CommentGroup, docTok string, tokStart, tokEnd token.Pos, name string) []analysis.SuggestedFix {
+	if !tokStart.IsValid() || !tokEnd.IsValid() || tokStart >= tokEnd {
+		return nil
+	}
+	edits := []analysis.TextEdit{{Pos: tokStart, End: tokEnd, NewText: []byte(name)}}
+	for _, pos := range wordOccurrences(doc, docTok) {
+		if pos != tokStart {
+			edits = append(edits, analysis.TextEdit{Pos: pos, End: pos + token.Pos(len(docTok)), NewText: []byte(name)})
+		}
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Pos < edits[j].Pos })
+	return []analysis.SuggestedFix{{
+		Message:   "replace doc token with symbol name",
+		TextEdits: edits,
+	}}
 }
 
 // checkInterfaceMethods inspects each interface method doc comment.
-func checkInterfaceMethods(pass *analysis.Pass, cfg matchConfig, iface *ast.InterfaceType) {
+func (c *checker) checkInterfaceMethods(iface *ast.InterfaceType) {
 	if iface == nil || iface.Methods == nil {
 		return
 	}
@@ -227,7 +494,97 @@ func checkInterfaceMethods(pass *analysis.Pass, cfg matchConfig, iface *ast.Inte
 			if name == nil {
 				continue
 			}
-			checkSymbol(pass, cfg, doc, name.Name, ast.IsExported(name.Name), kindFunc, name.Pos())
+			c.checkSymbol(doc, name.Name, ast.IsExported(name.Name), kindFunc, name.Pos())
+		}
+	}
+}
+
+// checkValueSpecs inspects const and var specs, falling back to the
+// declaration doc when the group holds a single spec. A doc shared by the
+// names of a spec may start with any of them.
+func (c *checker) checkValueSpecs(decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		doc := vs.Doc
+		if doc == nil && len(decl.Specs) == 1 {
+			doc = decl.Doc
+		}
+		if doc == nil || docNamesOneOf(doc, vs.Names) {
+			continue
+		}
+		for _, name := range vs.Names {
+			if name == nil || name.Name == "_" {
+				continue
+			}
+			c.checkSymbol(doc, name.Name, ast.IsExported(name.Name), kindValue, name.Pos())
+		}
+	}
+}
+
+// checkStructFields inspects the doc and trailing comments of each struct
+// field. As for value specs, a comment shared by several names may start
+// with any of them.
+func (c *checker) checkStructFields(st *ast.StructType) {
+	if st == nil || st.Fields == nil {
+		return
+	}
+
+	for _, field := range st.Fields.List {
+		if field == nil {
+			continue
+		}
+		names := field.Names
+		if len(names) == 0 {
+			if id := baseTypeIdent(field.Type); id != nil {
+				names = []*ast.Ident{id}
+			}
+		}
+		for _, doc := range []*ast.CommentGroup{field.Doc, field.Comment} {
+			if doc == nil || docNamesOneOf(doc, field.Names) {
+				continue
+			}
+			for _, name := range names {
+				if name == nil || name.Name == "_" {
+					continue
+				}
+				c.checkSymbol(doc, name.Name, ast.IsExported(name.Name), kindField, name.Pos())
+			}
+		}
+	}
+}
+
+// docNamesOneOf reports whether the doc token of doc is one of several
+// names that share it, as in "minRetry and maxRetry bound the delay".
+func docNamesOneOf(doc *ast.CommentGroup, names []*ast.Ident) bool {
+	if len(names) < 2 {
+		return false
+	}
+	tok, _, _, _ := firstIdentifierLike(doc)
+	return slices.ContainsFunc(names, func(id *ast.Ident) bool {
+		return id != nil && id.Name == tok
+	})
+}
+
+// baseTypeIdent returns the identifier naming a type expression, skipping
+// pointers, package qualifiers and type arguments.
+func baseTypeIdent(expr ast.Expr) *ast.Ident {
+	for {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return e
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			return e.Sel
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		default:
+			return nil
 		}
 	}
 }
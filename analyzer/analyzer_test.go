@@ -1,86 +1,214 @@
 package analyzer
 
 import (
+	"maps"
+	"strings"
 	"testing"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
 func TestAnalyzer(t *testing.T) {
-	t.Run("defaults", func(t *testing.T) {
-		resetFlags()
-		analysistest.Run(t, analysistest.TestData(), Analyzer, "unexported")
-	})
-
-	t.Run("exportedAndTypes", func(t *testing.T) {
-		resetFlags()
-		includeExportedFlag = true
-		includeTypesFlag = true
-		analysistest.Run(t, analysistest.TestData(), Analyzer, "exported")
-	})
-
-	t.Run("generatedOptIn", func(t *testing.T) {
-		resetFlags()
-		includeExportedFlag = true
-		includeGeneratedFlag = true
-		analysistest.Run(t, analysistest.TestData(), Analyzer, "generatedcode")
-	})
+	tests := []struct {
+		name      string
+		configure func(*Config)
+		pkg       string
+		fix       bool
+	}{
+		{name: "defaults", pkg: "unexported"},
+		{name: "exportedAndTypes", pkg: "exported", configure: func(c *Config) {
+			c.IncludeExported = true
+			c.IncludeTypes = true
+		}},
+		{name: "generatedOptIn", pkg: "generatedcode", configure: func(c *Config) {
+			c.IncludeExported = true
+			c.IncludeGenerated = true
+		}},
+		{name: "interfaceMethodsOptIn", pkg: "interfaces", configure: func(c *Config) {
+			c.IncludeInterfaceMethods = true
+		}},
+		{name: "valuesOptIn", pkg: "values", configure: func(c *Config) {
+			c.IncludeValues = true
+		}},
+		{name: "fieldsOptIn", pkg: "fields", configure: func(c *Config) {
+			c.IncludeFields = true
+		}},
+		{name: "fixSuggested", pkg: "fixes", fix: true},
+		{name: "fixOtherOccurrences", pkg: "fixoccurrences", fix: true},
+		{name: "renameFixSuggested", pkg: "renamefix", fix: true, configure: func(c *Config) {
+			c.IncludeFields = true
+		}},
+		{name: "narrativeLeadingWords", pkg: "narrative"},
+		{name: "allowedPrefixes", pkg: "prefixaliases", configure: func(c *Config) {
+			c.AllowedPrefixes = "asm,op"
+		}},
+		{name: "plainWordCamelFlag", pkg: "plainwordcamel"},
+		{name: "plainWordCamelFlagDisabled", pkg: "plainwordcamelexpect", configure: func(c *Config) {
+			c.SkipPlainWordCamel = false
+		}},
+		{name: "maxDistanceGate", pkg: "maxdistance", configure: func(c *Config) {
+			c.MaxDist = 5
+		}},
+		{name: "weightedDistance", pkg: "weighteddistance", configure: func(c *Config) {
+			c.MaxDist = 1
+			c.Distance = distanceWeighted
+			c.Explain = true
+		}},
+		{name: "camelChunkHeuristics", pkg: "camelchunks"},
+		{name: "minConfidence", pkg: "minconfidence", configure: func(c *Config) {
+			c.MinConfidence = 0.6
+			c.Explain = true
+		}},
+		{name: "unicodeIdentifiers", pkg: "unicodeidents", fix: true},
+		{name: "initialisms", pkg: "initialisms", fix: true},
+		{name: "abbreviations", pkg: "abbreviations"},
+		{name: "abbreviationsReported", pkg: "abbreviationsreport", fix: true, configure: func(c *Config) {
+			c.ReportAbbreviations = true
+			c.Abbreviations = defaultAbbreviations + ",opts=options"
+		}},
+		{name: "copyPasteDocs", pkg: "copypaste"},
+		{name: "qualifiedMethods", pkg: "qualifiedmethods", fix: true},
+		{name: "packageDoc", pkg: "pkgdoc", fix: true, configure: func(c *Config) {
+			c.IncludePackageDoc = true
+		}},
+		{name: "testNames", pkg: "testnames", fix: true, configure: func(c *Config) {
+			c.CheckTestNames = true
+		}},
+		{name: "docLinks", pkg: "doclinks", fix: true, configure: func(c *Config) {
+			c.CheckDocLinks = true
+			c.IncludeExported = true
+			c.IncludeTypes = true
+			c.IncludeFields = true
+			c.IncludePackageDoc = true
+		}},
+		{name: "docLinksIncludeSettings", pkg: "doclinkscope", configure: func(c *Config) {
+			c.CheckDocLinks = true
+		}},
+		{name: "commandDoc", pkg: "deploytool", configure: func(c *Config) {
+			c.IncludePackageDoc = true
+		}},
+		{name: "explainHeuristics", pkg: "explain", configure: func(c *Config) {
+			c.Explain = true
+		}},
+		{name: "legacyDeclarationPosition", pkg: "declposition", configure: func(c *Config) {
+			c.ReportAtDeclaration = true
+		}},
+		{name: "ignoreDirectives", pkg: "ignores", configure: func(c *Config) {
+			c.ReportUnusedIgnores = true
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := DefaultConfig()
+			if tt.configure != nil {
+				tt.configure(&cfg)
+			}
+			a := NewAnalyzer(cfg)
+			if tt.fix {
+				analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), a, tt.pkg)
+				return
+			}
+			analysistest.Run(t, analysistest.TestData(), a, tt.pkg)
+		})
+	}
+}
 
-	t.Run("interfaceMethodsOptIn", func(t *testing.T) {
-		resetFlags()
-		includeInterfaceMethodsFlag = true
-		analysistest.Run(t, analysistest.TestData(), Analyzer, "interfaces")
-	})
+func TestAnalyzerConfigFile(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(DefaultConfig()), "configfile", "configfile/nested")
 
-	t.Run("fixSuggested", func(t *testing.T) {
-		resetFlags()
-		analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "fixes")
-	})
+	a := NewAnalyzer(DefaultConfig())
+	if err := a.Flags.Set("include-types", "false"); err != nil {
+		t.Fatalf("set include-types: %v", err)
+	}
+	analysistest.Run(t, analysistest.TestData(), a, "configjson")
+}
 
-	t.Run("narrativeLeadingWords", func(t *testing.T) {
-		resetFlags()
-		analysistest.Run(t, analysistest.TestData(), Analyzer, "narrative")
-	})
+func TestAnalyzerOverrides(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(DefaultConfig()), "overrides", "overrides/pb", "overrides/pb/inner", "overrides/legacy")
 
-	t.Run("allowedPrefixes", func(t *testing.T) {
-		resetFlags()
-		allowedPrefixesFlag = "asm,op"
-		analysistest.Run(t, analysistest.TestData(), Analyzer, "prefixaliases")
-	})
+	cfg := DefaultConfig()
+	cfg.Overrides = []Override{{Packages: []string{"values"}, Settings: Settings{IncludeValues: ptr(true)}}}
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(cfg), "values")
+}
 
-	t.Run("plainWordCamelFlag", func(t *testing.T) {
-		resetFlags()
-		analysistest.Run(t, analysistest.TestData(), Analyzer, "plainwordcamel")
-	})
+func ptr[T any](v T) *T { return &v }
 
-	t.Run("plainWordCamelFlagDisabled", func(t *testing.T) {
-		resetFlags()
-		skipPlainWordCamelFlag = false
-		analysistest.Run(t, analysistest.TestData(), Analyzer, "plainwordcamelexpect")
-	})
+func TestAnalyzerFlagsAreIndependent(t *testing.T) {
+	a := NewAnalyzer(DefaultConfig())
+	if err := a.Flags.Set("include-values", "true"); err != nil {
+		t.Fatalf("set include-values: %v", err)
+	}
+	if got := Analyzer.Flags.Lookup("include-values").Value.String(); got != "false" {
+		t.Fatalf("default Analyzer include-values = %s, want false", got)
+	}
+	analysistest.Run(t, analysistest.TestData(), a, "values")
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(DefaultConfig()), "plainwordcamel")
+}
 
-	t.Run("maxDistanceGate", func(t *testing.T) {
-		resetFlags()
-		maxDistFlag = 5
-		analysistest.Run(t, analysistest.TestData(), Analyzer, "maxdistance")
-	})
+func TestDiagnosticRange(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), NewAnalyzer(DefaultConfig()), "copypaste")
+	var found bool
+	for _, r := range results {
+		fset := r.Action.Package.Fset
+		for _, d := range r.Action.Diagnostics {
+			if d.Category != ruleCopyPaste {
+				continue
+			}
+			found = true
+			start, end := fset.Position(d.Pos), fset.Position(d.End)
+			if start.Line != 6 || start.Column != 4 || end.Column-start.Column != len("parseHeader") {
+				t.Errorf("diagnostic range = %s-%s, want the doc token on line 6", start, end)
+			}
+			if len(d.Related) != 2 {
+				t.Fatalf("got %d related locations, want declaration and other declaration", len(d.Related))
+			}
+			if decl := fset.Position(d.Related[0].Pos); decl.Line != 7 || d.Related[0].Message != "'parseFooter' is declared here" {
+				t.Errorf("first related location = %s %q, want the parseFooter declaration", decl, d.Related[0].Message)
+			}
+			return
+		}
+	}
+	if !found {
+		t.Fatalf("no copy-paste diagnostic reported")
+	}
+}
 
-	t.Run("camelChunkHeuristics", func(t *testing.T) {
-		resetFlags()
-		analysistest.Run(t, analysistest.TestData(), Analyzer, "camelchunks")
-	})
+// TestAnalyzerFlagsAvoidDriverFlags guards against flags that singlechecker
+// and multichecker would silently drop because the driver defines them.
+func TestAnalyzerFlagsAvoidDriverFlags(t *testing.T) {
+	for _, name := range []string{"V", "c", "cpuprofile", "debug", "diff", "fix", "flags", "json", "memprofile", "source", "tags", "test", "trace", "v"} {
+		if Analyzer.Flags.Lookup(name) != nil {
+			t.Errorf("analyzer flag -%s conflicts with the analysis driver", name)
+		}
+	}
 }
 
-func resetFlags() {
-	maxDistFlag = 5
-	includeUnexportedFlag = true
-	includeExportedFlag = false
-	includeTypesFlag = false
-	includeGeneratedFlag = false
-	includeInterfaceMethodsFlag = false
-	allowedLeadingWordsFlag = defaultAllowedLeadingWords
-	allowedPrefixesFlag = ""
-	skipPlainWordCamelFlag = true
-	maxCamelChunkInsertFlag = 2
-	maxCamelChunkReplaceFlag = 2
+func TestDiagnosticConfidence(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), NewAnalyzer(DefaultConfig()), "camelchunks")
+	got := make(map[string]float64)
+	for _, r := range results {
+		result := r.Result.(*Result)
+		for _, d := range r.Action.Diagnostics {
+			if strings.Contains(d.Message, "confidence") {
+				t.Errorf("confidence in %q without -explain", d.Message)
+			}
+			c, ok := result.Confidence(d)
+			if !ok {
+				t.Errorf("no confidence for %q", d.Message)
+			}
+			tok, _, _ := strings.Cut(strings.TrimPrefix(d.Message, "doc comment starts with '"), "'")
+			got[tok] = c
+		}
+	}
+	// Replacing or leaving out a whole word is less certain than a typo,
+	// and more so the fewer words are left.
+	want := map[string]float64{"processCIDRs": 0.4, "handleVolume": 0.53, "syncHandler": 0.4}
+	if !maps.Equal(got, want) {
+		t.Errorf("confidences = %v, want %v", got, want)
+	}
+	if _, ok := results[0].Result.(*Result).Confidence(analysis.Diagnostic{Message: "unused ignore directive '//docnametypo:ignore'"}); ok {
+		t.Errorf("unused ignore directive has a confidence")
+	}
 }
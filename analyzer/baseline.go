@@ -0,0 +1,271 @@
+package analyzer
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// baselineVersion is the format version written to baseline files.
+const baselineVersion = 1
+
+// baselineEntry identifies a finding without line numbers, so unrelated edits
+// do not invalidate it. File is only used to tell which entries a pass could
+// have reproduced; it is not part of the match.
+type baselineEntry struct {
+	Package string `json:"package"`
+	File    string `json:"file"`
+	Symbol  string `json:"symbol"`
+	Kind    string `json:"kind"`
+	Token   string `json:"token"`
+}
+
+// baselineKey is the part of an entry that must match a finding.
+type baselineKey struct {
+	pkg, symbol, kind, token string
+}
+
+func (e baselineEntry) key() baselineKey {
+	return baselineKey{pkg: e.Package, symbol: e.Symbol, kind: e.Kind, token: e.Token}
+}
+
+// baselineFile is the on-disk layout of a baseline.
+type baselineFile struct {
+	Version int             `json:"version"`
+	Entries []baselineEntry `json:"entries"`
+}
+
+// baselineStore loads baseline files once for all passes of an analyzer.
+type baselineStore struct {
+	mu     sync.Mutex
+	loaded map[string]loadedBaseline
+}
+
+type loadedBaseline struct {
+	entries []baselineEntry
+	err     error
+}
+
+func newBaselineStore() *baselineStore {
+	return &baselineStore{loaded: make(map[string]loadedBaseline)}
+}
+
+// load reads the baseline at path once and returns its entries.
+func (s *baselineStore) load(path string) ([]baselineEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lb, ok := s.loaded[path]; ok {
+		return lb.entries, lb.err
+	}
+	entries, err := readBaseline(path)
+	s.loaded[path] = loadedBaseline{entries: entries, err: err}
+	return entries, err
+}
+
+// WriteBaseline writes the findings recorded by passes run with
+// BaselineWrite, whose results are given, to the baseline file. Drivers call
+// it once every pass is done, so the file is written once and holds the
+// findings of all packages. Results of other passes are ignored.
+func WriteBaseline(results []*Result) error {
+	recorded := make(map[string]map[baselineEntry]struct{})
+	for _, r := range results {
+		if r == nil || r.baselinePath == "" {
+			continue
+		}
+		set := recorded[r.baselinePath]
+		if set == nil {
+			set = make(map[baselineEntry]struct{})
+			recorded[r.baselinePath] = set
+		}
+		for _, e := range r.baselineEntries {
+			set[e] = struct{}{}
+		}
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(recorded)) {
+		set := recorded[path]
+		out := baselineFile{Version: baselineVersion, Entries: make([]baselineEntry, 0, len(set))}
+		for e := range set {
+			out.Entries = append(out.Entries, e)
+		}
+		slices.SortFunc(out.Entries, func(a, b baselineEntry) int {
+			return cmp.Or(
+				cmp.Compare(a.Package, b.Package),
+				cmp.Compare(a.File, b.File),
+				cmp.Compare(a.Symbol, b.Symbol),
+				cmp.Compare(a.Kind, b.Kind),
+				cmp.Compare(a.Token, b.Token),
+			)
+		})
+		if err := writeBaseline(path, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBaseline decodes a baseline file.
+func readBaseline(path string) ([]baselineEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("docnametypo baseline: %w", err)
+	}
+	var bf baselineFile
+	if err := json.Unmarshal(data, &bf); err != nil {
+		return nil, fmt.Errorf("docnametypo baseline %s: %w", path, err)
+	}
+	if bf.Version != baselineVersion {
+		return nil, fmt.Errorf("docnametypo baseline %s: unsupported version %d", path, bf.Version)
+	}
+	return bf.Entries, nil
+}
+
+// writeBaseline replaces the file at path atomically.
+func writeBaseline(path string, bf baselineFile) error {
+	data, err := json.MarshalIndent(bf, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("docnametypo baseline: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("docnametypo baseline: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("docnametypo baseline: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("docnametypo baseline: %w", err)
+	}
+	return nil
+}
+
+// passBaseline applies a baseline to the findings of a single pass.
+type passBaseline struct {
+	// writePath is set when findings are recorded instead of reported.
+	writePath string
+	recorded  []baselineEntry
+	// known holds the entries of the pass's package and whether a finding
+	// matched them.
+	known   map[baselineKey]bool
+	entries []baselineEntry
+	path    string
+}
+
+// newPassBaseline prepares baseline handling for pass, or returns nil when
+// neither -baseline nor -baseline-write is set.
+func newPassBaseline(pass *analysis.Pass, cfg Config, store *baselineStore) (*passBaseline, error) {
+	if cfg.Baseline != "" && cfg.BaselineWrite != "" {
+		return nil, errors.New("docnametypo: -baseline and -baseline-write cannot be used together")
+	}
+	if cfg.BaselineWrite != "" {
+		return &passBaseline{writePath: cfg.BaselineWrite}, nil
+	}
+	if cfg.Baseline == "" {
+		return nil, nil
+	}
+	all, err := store.load(cfg.Baseline)
+	if err != nil {
+		return nil, err
+	}
+	pb := &passBaseline{known: make(map[baselineKey]bool), path: cfg.Baseline}
+	for _, e := range all {
+		if e.Package != pass.Pkg.Path() {
+			continue
+		}
+		pb.known[e.key()] = false
+		pb.entries = append(pb.entries, e)
+	}
+	return pb, nil
+}
+
+// suppresses records e when writing a baseline, or reports whether the
+// baseline already covers it.
+func (pb *passBaseline) suppresses(e baselineEntry) bool {
+	if pb.writePath != "" {
+		pb.recorded = append(pb.recorded, e)
+		return true
+	}
+	if _, ok := pb.known[e.key()]; !ok {
+		return false
+	}
+	pb.known[e.key()] = true
+	return true
+}
+
+// finish hands the recorded findings to result for WriteBaseline, or reports
+// the baseline entries that no longer match a finding: those for the files of
+// this pass, and those for files of the package that no longer exist, which
+// are reported at the package clause of the pass's first file.
+func (pb *passBaseline) finish(pass *analysis.Pass, result *Result) {
+	if pb.writePath != "" {
+		result.baselinePath = pb.writePath
+		result.baselineEntries = pb.recorded
+		return
+	}
+	if len(pass.Files) == 0 {
+		return
+	}
+
+	files := make(map[string]*ast.File, len(pass.Files))
+	for _, f := range pass.Files {
+		if tf := pass.Fset.File(f.Pos()); tf != nil {
+			files[filepath.Base(tf.Name())] = f
+		}
+	}
+	dir := packageDir(pass)
+	for _, e := range pb.entries {
+		if pb.known[e.key()] {
+			continue
+		}
+		f, ok := files[e.File]
+		if !ok {
+			if dir == "" || !isNotExist(filepath.Join(dir, e.File)) {
+				continue
+			}
+			f = pass.Files[0]
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:      f.Name.Pos(),
+			End:      f.Name.End(),
+			Category: categoryStaleBaseline,
+			Message:  "stale baseline entry: " + e.Kind + " '" + e.Symbol + "' no longer has a finding for doc token '" + e.Token + "' (remove it from " + pb.path + ")",
+		})
+	}
+}
+
+// isNotExist reports whether there is no file at path.
+func isNotExist(path string) bool {
+	_, err := os.Stat(path)
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// baselineEntry builds the baseline entry describing f.
+func (c *checker) baselineEntry(f finding) baselineEntry {
+	file := ""
+	if tf := c.pass.Fset.File(f.declPos); tf != nil {
+		file = filepath.Base(tf.Name())
+	}
+	return baselineEntry{
+		Package: c.pass.Pkg.Path(),
+		File:    file,
+		Symbol:  f.name,
+		Kind:    f.kind.String(),
+		Token:   f.docTok,
+	}
+}
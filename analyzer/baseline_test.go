@@ -0,0 +1,47 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestBaseline(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Baseline = filepath.Join(analysistest.TestData(), "baseline.json")
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(cfg), "baseline")
+}
+
+func TestBaselineWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	cfg := DefaultConfig()
+	cfg.BaselineWrite = path
+	var results []*Result
+	for _, r := range analysistest.Run(t, analysistest.TestData(), NewAnalyzer(cfg), "baselinewrite") {
+		results = append(results, r.Result.(*Result))
+	}
+	if _, err := readBaseline(path); err == nil {
+		t.Fatalf("baseline written before WriteBaseline")
+	}
+	if err := WriteBaseline(results); err != nil {
+		t.Fatalf("WriteBaseline: %v", err)
+	}
+
+	got, err := readBaseline(path)
+	if err != nil {
+		t.Fatalf("readBaseline: %v", err)
+	}
+	want := []baselineEntry{
+		{Package: "baselinewrite", File: "baselinewrite.go", Symbol: "dial", Kind: "func", Token: "dialRemote"},
+		{Package: "baselinewrite", File: "baselinewrite.go", Symbol: "parseConfig", Kind: "func", Token: "parseConfg"},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("baseline entries = %+v, want %+v", got, want)
+	}
+
+	cfg = DefaultConfig()
+	cfg.Baseline = path
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(cfg), "baselinewrite")
+}
@@ -114,7 +114,7 @@ func isCamelSwapVariant(docToken, symbol string) bool {
 }
 
 // hasSimilarCamelWord allows a single camel chunk to be a close typo.
-func hasSimilarCamelWord(docToken, symbol string) bool {
+func hasSimilarCamelWord(cfg matchConfig, docToken, symbol string) bool {
 	docWords := splitCamelWords(docToken)
 	symWords := splitCamelWords(symbol)
 	if len(docWords) == 0 || len(docWords) != len(symWords) {
@@ -126,7 +126,7 @@ func hasSimilarCamelWord(docToken, symbol string) bool {
 		if a == b {
 			return true
 		}
-		if mismatches == 1 || !wordClose(a, b) {
+		if mismatches == 1 || !wordClose(cfg, a, b) {
 			return false
 		}
 		mismatches++
@@ -135,8 +135,9 @@ func hasSimilarCamelWord(docToken, symbol string) bool {
 	return closeEnough && mismatches == 1
 }
 
-// wordClose reports whether two words are similar under distance heuristics.
-func wordClose(a, b string) bool {
+// wordClose reports whether two words are similar under distance heuristics,
+// using the configured distance metric.
+func wordClose(cfg matchConfig, a, b string) bool {
 	if a == "" || b == "" {
 		return false
 	}
@@ -146,12 +147,11 @@ func wordClose(a, b string) bool {
 		return true
 	}
 
-	dist := damerauLevenshtein(al, bl)
-	if dist > maxDistFlag+1 {
+	if cfg.distance(al, bl) > float64(cfg.MaxDist+1) {
 		return false
 	}
 
-	minLen := min(len(al), len(bl))
+	minLen := min(utf8.RuneCountInString(al), utf8.RuneCountInString(bl))
 	if minLen <= 1 {
 		return false
 	}
@@ -165,61 +165,96 @@ func wordClose(a, b string) bool {
 	return prefix >= threshold || suffix >= threshold
 }
 
-// hasSmallChunkDifference allows a small suffix/prefix chunk variance.
+// hasSmallChunkDifference allows a small suffix/prefix chunk variance: a
+// run of at most maxChunk runes inserted into one of the strings.
 func hasSmallChunkDifference(a, b string, maxChunk int) bool {
 	if maxChunk <= 0 {
 		return false
 	}
-	if len(a) == len(b) {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == len(rb) {
 		return false
 	}
-	if len(a) < len(b) {
-		return hasSmallChunkDifference(b, a, maxChunk)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
 	}
 
-	diff := len(a) - len(b)
+	diff := len(ra) - len(rb)
 	if diff > maxChunk {
 		return false
 	}
-	for i := 0; i <= len(a)-diff; i++ {
-		if strings.HasPrefix(b, a[:i]) && strings.HasSuffix(b, a[i+diff:]) {
+	for i := 0; i <= len(rb); i++ {
+		if slices.Equal(ra[:i], rb[:i]) && slices.Equal(ra[i+diff:], rb[i:]) {
 			return true
 		}
 	}
 	return false
 }
 
-// splitCamelWords tokenizes a camelCase or snake_case identifier.
+// splitCamelWords tokenizes a camelCase or snake_case identifier into
+// lowercase words.
 func splitCamelWords(s string) []string {
+	chunks := splitCamelChunks(s)
+	for i, c := range chunks {
+		chunks[i] = strings.ToLower(c)
+	}
+	return chunks
+}
+
+// splitCamelChunks tokenizes a camelCase or snake_case identifier, keeping
+// the case of each chunk. A plural initialism such as URLs or IDs stays one
+// chunk, so parseURLs and parseUrls split alike.
+func splitCamelChunks(s string) []string {
 	s = strings.ReplaceAll(s, "_", "")
 	if s == "" {
 		return nil
 	}
 	if !utf8.ValidString(s) {
-		return []string{strings.ToLower(s)}
+		return []string{s}
 	}
 
 	rawParts := camelcase.Split(s)
 	if len(rawParts) == 0 {
-		return []string{strings.ToLower(s)}
+		return []string{s}
 	}
 	rawParts = slices.DeleteFunc(rawParts, func(part string) bool { return part == "" })
 	if len(rawParts) == 0 {
-		return []string{strings.ToLower(s)}
+		return []string{s}
 	}
 
 	words := make([]string, 0, len(rawParts))
 	for i := 0; i < len(rawParts); i++ {
 		part := rawParts[i]
-		if i+1 < len(rawParts) && shouldMergeCamelParts(part, rawParts[i+1]) {
+		switch {
+		case i+1 < len(rawParts) && isPluralInitialismTail(part, rawParts[i+1]):
+			part += rawParts[i+1]
+			i++
+		case i+1 < len(rawParts) && shouldMergeCamelParts(part, rawParts[i+1]):
 			part += rawParts[i+1]
 			i++
 		}
-		words = append(words, strings.ToLower(part))
+		words = append(words, part)
 	}
 	return words
 }
 
+// isPluralInitialismTail reports whether b is the last letter of the
+// upper case run a followed by a plural "s", as camelcase.Split splits URLs
+// into "UR" and "Ls".
+func isPluralInitialismTail(a, b string) bool {
+	if a == "" || strings.ToUpper(a) != a || !unicode.IsUpper(firstRune(a)) {
+		return false
+	}
+	r, size := utf8.DecodeRuneInString(b)
+	return unicode.IsUpper(r) && b[size:] == "s"
+}
+
+// firstRune returns the first rune of s.
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
+
 func shouldMergeCamelParts(a, b string) bool {
 	if len(a) != 1 {
 		return false
@@ -76,3 +76,24 @@ func TestSplitCamelWords(t *testing.T) {
 		}
 	}
 }
+
+func TestHasSmallChunkDifference(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		maxChunk int
+		want     bool
+	}{
+		{"parseHeader", "parseHeaders", 1, true},
+		{"parseHeader", "parseHeaderList", 4, true},
+		{"parseHeader", "parseHeaderList", 3, false},
+		// "ß" is one rune but two bytes.
+		{"straeName", "straßeName", 1, true},
+		{"σύνολο", "σύνολοΑ", 1, true},
+		{"größe", "grüße", 2, false},
+	}
+	for _, tt := range tests {
+		if got := hasSmallChunkDifference(tt.a, tt.b, tt.maxChunk); got != tt.want {
+			t.Errorf("hasSmallChunkDifference(%q,%q,%d)=%v, want %v", tt.a, tt.b, tt.maxChunk, got, tt.want)
+		}
+	}
+}
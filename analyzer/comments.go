@@ -3,7 +3,9 @@ package analyzer
 import (
 	"go/ast"
 	"go/token"
+	"regexp"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -15,7 +17,7 @@ func firstIdentifierLike(cg *ast.CommentGroup) (string, token.Pos, token.Pos, st
 	if cg == nil || len(cg.List) == 0 {
 		return "", token.NoPos, token.NoPos, ""
 	}
-	comment := cg.List[0]
+	comment := firstDocComment(cg)
 	line, lineOffset := firstDocLine(comment.Text)
 	if line == "" {
 		return "", token.NoPos, token.NoPos, ""
@@ -29,6 +31,57 @@ func firstIdentifierLike(cg *ast.CommentGroup) (string, token.Pos, token.Pos, st
 	return id, start, end, line
 }
 
+// firstDocComment returns the first comment of cg that is not a directive,
+// or the first comment if all of them are.
+func firstDocComment(cg *ast.CommentGroup) *ast.Comment {
+	for _, c := range cg.List {
+		if !isDirectiveComment(c.Text) {
+			return c
+		}
+	}
+	return cg.List[0]
+}
+
+// qualifiedName is a receiver-qualified method name such as T.Method or
+// (*T).Method at the start of a doc comment, with the range of each half.
+type qualifiedName struct {
+	recv, method           string
+	recvStart, recvEnd     token.Pos
+	methodStart, methodEnd token.Pos
+}
+
+// qualifiedMethodRE matches T.Method, (T).Method and (*T).Method, optionally
+// with type parameters on T, a trailing () and trailing punctuation.
+var qualifiedMethodRE = regexp.MustCompile(`^(?:\(\*?([\pL_][\pL\pN_]*)(?:\[[^\]]*\])?\)|([\pL_][\pL\pN_]*)(?:\[[^\]]*\])?)\.([\pL_][\pL\pN_]*)(?:\(\))?[,.:;]?$`)
+
+// qualifiedMethodName parses a receiver-qualified method name from the first
+// word of the first line of cg.
+func qualifiedMethodName(cg *ast.CommentGroup) (qualifiedName, bool) {
+	if cg == nil || len(cg.List) == 0 {
+		return qualifiedName{}, false
+	}
+	comment := firstDocComment(cg)
+	line, lineOffset := firstDocLine(comment.Text)
+	word, _, _ := strings.Cut(strings.ReplaceAll(line, "\t", " "), " ")
+	m := qualifiedMethodRE.FindStringSubmatchIndex(word)
+	if m == nil {
+		return qualifiedName{}, false
+	}
+	recvStart, recvEnd := m[2], m[3]
+	if recvStart < 0 {
+		recvStart, recvEnd = m[4], m[5]
+	}
+	base := comment.Slash + token.Pos(lineOffset)
+	return qualifiedName{
+		recv:        word[recvStart:recvEnd],
+		method:      word[m[6]:m[7]],
+		recvStart:   base + token.Pos(recvStart),
+		recvEnd:     base + token.Pos(recvEnd),
+		methodStart: base + token.Pos(m[6]),
+		methodEnd:   base + token.Pos(m[7]),
+	}, true
+}
+
 // firstDocLine returns the first non-empty line of the raw comment text.
 func firstDocLine(raw string) (string, int) {
 	if raw == "" {
@@ -155,32 +208,18 @@ func trimPointerPrefixes(s string) (string, int) {
 	return trimmed, len(s) - len(trimmed)
 }
 
-// leadingIdentRun reads the initial identifier characters from a string.
+// leadingIdentRun reads the initial identifier characters from a string,
+// following the Go spec: Unicode letters, decimal digits and underscores.
 func leadingIdentRun(s string) (string, int) {
-	var b strings.Builder
 	i := 0
 	for i < len(s) {
 		r, size := utf8.DecodeRuneInString(s[i:])
-		if r == '-' || r == '.' || r == '"' || r == '\'' {
-			break
-		}
-		if r == '\n' || r == '\r' || r == '\t' || r == ' ' {
+		if !isIdentRune(r) {
 			break
 		}
-		if r == ':' || r == ';' || r == ',' || r == ')' || r == '(' || r == ']' || r == '[' || r == '{' || r == '}' {
-			break
-		}
-		if ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') || r == '_' {
-			b.WriteRune(r)
-			i += size
-			continue
-		}
-		break
-	}
-	if b.Len() == 0 {
-		return "", 0
+		i += size
 	}
-	return b.String(), i
+	return s[:i], i
 }
 
 // extractIdentifierToken pulls the last identifier component from a token.
@@ -203,3 +242,40 @@ func isSkippableLabel(word string) bool {
 	}
 	return false
 }
+
+// wordOccurrences returns the start of every whole-word occurrence of word in
+// the non-directive comments of cg. Occurrences that continue a longer
+// identifier or follow a '.' qualifier, as in pkg.word, are not included.
+func wordOccurrences(cg *ast.CommentGroup, word string) []token.Pos {
+	if cg == nil || word == "" {
+		return nil
+	}
+	var out []token.Pos
+	for _, c := range cg.List {
+		if isDirectiveComment(c.Text) {
+			continue
+		}
+		text := c.Text
+		for i := 0; ; {
+			j := strings.Index(text[i:], word)
+			if j < 0 {
+				break
+			}
+			start, end := i+j, i+j+len(word)
+			i = end
+			if r, _ := utf8.DecodeLastRuneInString(text[:start]); isIdentRune(r) || r == '.' {
+				continue
+			}
+			if r, _ := utf8.DecodeRuneInString(text[end:]); isIdentRune(r) {
+				continue
+			}
+			out = append(out, c.Slash+token.Pos(start))
+		}
+	}
+	return out
+}
+
+// isIdentRune reports whether r may appear in a Go identifier.
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,24 @@
+package analyzer
+
+import "testing"
+
+func TestExtractIdentifierToken(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"parseHeader", "parseHeader"},
+		{"*Server", "Server"},
+		{"größeBerechnen", "größeBerechnen"},
+		{"σύνολο's", "σύνολο"},
+		{"数据加载", "数据加载"},
+		{"café_открыть()", "café_открыть"},
+		{"v٣", "v٣"},
+		{"—dash", ""},
+	}
+	for _, tt := range tests {
+		if got, _ := extractIdentifierToken(tt.word); got != tt.want {
+			t.Errorf("extractIdentifierToken(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
@@ -1,21 +1,233 @@
 package analyzer
 
 import (
+	"io"
 	"slices"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// Config controls which declarations the analyzer checks and how closely a
+// doc token must resemble the symbol name before it is reported.
+type Config struct {
+	// MaxDist is the maximum edit distance, measured by Distance, considered a typo.
+	MaxDist int
+	// Distance selects the edit distance compared with MaxDist:
+	// "damerau-levenshtein", where every edit costs 1, or "weighted", where
+	// neighbouring-key substitutions, doubled letters and dropped vowels
+	// cost less. Empty means "damerau-levenshtein".
+	Distance string
+	// MinConfidence drops findings whose confidence, from 0 to 1, is below
+	// it. The other limits still cap what each heuristic matches.
+	MinConfidence float64
+	// IncludeUnexported checks unexported declarations.
+	IncludeUnexported bool
+	// IncludeExported checks exported declarations.
+	IncludeExported bool
+	// IncludeTypes checks type declarations.
+	IncludeTypes bool
+	// IncludeGenerated checks files marked as generated.
+	IncludeGenerated bool
+	// IncludeInterfaceMethods checks interface method declarations.
+	IncludeInterfaceMethods bool
+	// IncludeValues checks const and var declarations.
+	IncludeValues bool
+	// IncludeFields checks struct field doc and trailing comments.
+	IncludeFields bool
+	// IncludePackageDoc checks the name in "// Package name" file doc
+	// comments, and the command name in "// Command name" package main docs.
+	IncludePackageDoc bool
+	// CheckDocLinks reports doc links such as [Config] or [*Server.Close]
+	// that no longer resolve, suggesting the closest declared name. Only the
+	// doc comments of declarations selected by the Include settings are read.
+	CheckDocLinks bool
+	// CheckTestNames reports Example, Test, Benchmark and Fuzz functions
+	// whose names refer to a near miss of a declared identifier.
+	CheckTestNames bool
+	// AllowedLeadingWords is a comma-separated list of narrative leading words.
+	AllowedLeadingWords string
+	// AllowedPrefixes is a comma-separated list of symbol prefixes that may be
+	// dropped in doc comments.
+	AllowedPrefixes string
+	// Initialisms is a comma-separated list of initialisms, such as ID or
+	// URL. A doc token that differs from the symbol only in the case of
+	// these, like userId for userID, is an initialism mismatch rather than
+	// a case mismatch.
+	Initialisms string
+	// ReportInitialisms reports initialism mismatches. They are a style
+	// issue rather than a typo, so they can be turned off separately.
+	ReportInitialisms bool
+	// Abbreviations is a comma-separated list of abbr=word pairs, such as
+	// cfg=config. A camelCase chunk spelled either way compares equal, so
+	// loadConfig documents loadCfg.
+	Abbreviations string
+	// ReportAbbreviations reports doc tokens that differ from the symbol
+	// only by an abbreviation as inconsistent, instead of accepting them.
+	ReportAbbreviations bool
+	// SkipPlainWordCamel skips plain leading words when the symbol is camelCase.
+	SkipPlainWordCamel bool
+	// MaxCamelChunkInsert is the number of camelCase chunks that may be
+	// inserted or removed.
+	MaxCamelChunkInsert int
+	// MaxCamelChunkReplace is the number of camelCase chunks that may be replaced.
+	MaxCamelChunkReplace int
+	// ReportUnusedIgnores flags docnametypo ignore directives that do not
+	// suppress any finding.
+	ReportUnusedIgnores bool
+	// Explain appends the matching heuristic, its measurements and the
+	// finding's confidence to each diagnostic message.
+	Explain bool
+	// ReportAtDeclaration reports findings at the declared name instead of
+	// the doc token, as earlier releases did.
+	ReportAtDeclaration bool
+	// Trace is a regular expression selecting symbol names whose evaluation
+	// is written step by step to TraceOutput.
+	Trace string
+	// TraceOutput receives the trace; nil means standard error.
+	TraceOutput io.Writer
+	// ConfigFile names the project configuration file to use. When empty,
+	// the file is discovered from each package directory.
+	ConfigFile string
+	// Baseline names a file of known findings that are not reported.
+	// Baseline entries that no longer match a finding are reported instead.
+	Baseline string
+	// BaselineWrite names a file to record the current findings into
+	// instead of reporting them. Each pass returns its findings in its
+	// Result, and WriteBaseline writes them once all passes are done.
+	BaselineWrite string
+	// GitHistory reports doc tokens that no heuristic matches when recent
+	// git history shows the symbol was renamed from that token.
+	GitHistory bool
+	// GitHistoryDepth is the number of recent commits of each file that
+	// GitHistory inspects.
+	GitHistoryDepth int
+	// NewFromRev keeps only findings whose doc comment or declaration
+	// overlaps lines changed since this git revision, including uncommitted
+	// and untracked files.
+	NewFromRev string
+	// NewFromPatch keeps only findings whose doc comment or declaration
+	// overlaps lines changed by this unified diff file.
+	NewFromPatch string
+	// Overrides change settings for matching packages. They are applied
+	// after the configuration file and flags, in order.
+	Overrides []Override
+}
+
+// DefaultConfig returns the configuration used by the command-line defaults.
+func DefaultConfig() Config {
+	return Config{
+		MaxDist:              5,
+		Distance:             distanceDamerau,
+		IncludeUnexported:    true,
+		AllowedLeadingWords:  defaultAllowedLeadingWords,
+		Initialisms:          defaultInitialisms,
+		ReportInitialisms:    true,
+		Abbreviations:        defaultAbbreviations,
+		SkipPlainWordCamel:   true,
+		MaxCamelChunkInsert:  2,
+		MaxCamelChunkReplace: 2,
+		GitHistoryDepth:      20,
+	}
+}
+
 type matchConfig struct {
+	Config
 	allowedLeadingWords map[string]struct{}
 	allowedPrefixes     []string
+	initialisms         map[string]bool
+	// abbreviations maps lowercase abbreviations to the lowercase words
+	// they stand for.
+	abbreviations map[string]string
 }
 
 // newMatchConfig builds the configuration used for doc/token comparisons.
-func newMatchConfig() matchConfig {
+func newMatchConfig(c Config) matchConfig {
 	return matchConfig{
-		allowedLeadingWords: buildAllowedLeadingWords(allowedLeadingWordsFlag),
-		allowedPrefixes:     splitCSV(allowedPrefixesFlag),
+		Config:              c,
+		allowedLeadingWords: buildAllowedLeadingWords(c.AllowedLeadingWords),
+		allowedPrefixes:     splitCSV(c.AllowedPrefixes),
+		initialisms:         buildInitialisms(c.Initialisms),
+		abbreviations:       buildAbbreviations(c.Abbreviations),
+	}
+}
+
+// distance returns the edit distance between two lowercased words under the
+// configured metric.
+func (c matchConfig) distance(a, b string) float64 {
+	if c.Distance == distanceWeighted {
+		return weightedDistance(a, b)
+	}
+	return float64(damerauLevenshtein(a, b))
+}
+
+// distanceRule returns the rule that names matches found by distance.
+func (c matchConfig) distanceRule() string {
+	if c.Distance == distanceWeighted {
+		return ruleWeightedDistance
+	}
+	return ruleDistance
+}
+
+// buildAbbreviations parses abbr=word pairs, ignoring entries without both.
+func buildAbbreviations(raw string) map[string]string {
+	abbreviations := make(map[string]string)
+	for _, pair := range splitCSV(raw) {
+		abbr, word, ok := strings.Cut(pair, "=")
+		if ok && abbr != "" && word != "" {
+			abbreviations[strings.ToLower(abbr)] = strings.ToLower(word)
+		}
+	}
+	return abbreviations
+}
+
+// expandAbbreviations spells out the abbreviated camelCase chunks of s,
+// keeping each chunk's case, as loadCfg becomes loadConfig. It returns s
+// unchanged when no chunk is abbreviated.
+func (c matchConfig) expandAbbreviations(s string) string {
+	if len(c.abbreviations) == 0 {
+		return s
+	}
+	chunks := splitCamelChunks(s)
+	changed := false
+	for i, chunk := range chunks {
+		word, ok := c.abbreviations[strings.ToLower(chunk)]
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.ToUpper(chunk) == chunk && len(chunk) > 1:
+			word = strings.ToUpper(word)
+		case unicode.IsUpper(firstRune(chunk)):
+			r, size := utf8.DecodeRuneInString(word)
+			word = string(unicode.ToUpper(r)) + word[size:]
+		}
+		chunks[i], changed = word, true
+	}
+	if !changed {
+		return s
+	}
+	return strings.Join(chunks, "")
+}
+
+// buildInitialisms returns the upper case initialisms in raw.
+func buildInitialisms(raw string) map[string]bool {
+	initialisms := make(map[string]bool)
+	for _, w := range splitCSV(raw) {
+		initialisms[strings.ToUpper(w)] = true
+	}
+	return initialisms
+}
+
+// isInitialism reports whether chunk, in any case, is a configured
+// initialism or the plural of one.
+func (c matchConfig) isInitialism(chunk string) bool {
+	upper := strings.ToUpper(chunk)
+	if c.initialisms[upper] {
+		return true
 	}
+	base, plural := strings.CutSuffix(upper, "S")
+	return plural && c.initialisms[base]
 }
 
 // isAllowedLeadingWord reports whether the token is in the narrative word list.
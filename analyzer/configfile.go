@@ -0,0 +1,199 @@
+package analyzer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileNames lists the project configuration files, in lookup order.
+var configFileNames = []string{".docnametypo.yaml", ".docnametypo.yml", ".docnametypo.json"}
+
+// configFile is a loaded project configuration file.
+type configFile struct {
+	path     string
+	settings Settings
+}
+
+// configLoader finds and caches project configuration files by directory.
+type configLoader struct {
+	mu    sync.Mutex
+	dirs  map[string]*configFile
+	files map[string]loadedConfig
+}
+
+type loadedConfig struct {
+	file *configFile
+	err  error
+}
+
+func newConfigLoader() *configLoader {
+	return &configLoader{
+		dirs:  make(map[string]*configFile),
+		files: make(map[string]loadedConfig),
+	}
+}
+
+// find returns the configuration file closest to dir, searching parent
+// directories up to and including the module root (the first directory
+// holding a go.mod). It returns nil when there is no such file.
+func (l *configLoader) find(dir string) (*configFile, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var visited []string
+	cf, err := l.findLocked(dir, &visited)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range visited {
+		l.dirs[d] = cf
+	}
+	return cf, nil
+}
+
+func (l *configLoader) findLocked(dir string, visited *[]string) (*configFile, error) {
+	for dir != "" {
+		if cf, ok := l.dirs[dir]; ok {
+			return cf, nil
+		}
+		*visited = append(*visited, dir)
+
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return nil, err
+			}
+			return l.loadLocked(path)
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return nil, nil
+}
+
+// load reads the configuration file at path.
+func (l *configLoader) load(path string) (*configFile, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.loadLocked(path)
+}
+
+func (l *configLoader) loadLocked(path string) (*configFile, error) {
+	if lc, ok := l.files[path]; ok {
+		return lc.file, lc.err
+	}
+	cf, err := readConfigFile(path)
+	l.files[path] = loadedConfig{file: cf, err: err}
+	return cf, err
+}
+
+// readConfigFile decodes a YAML or JSON configuration file, rejecting keys
+// that do not correspond to an analyzer setting.
+func readConfigFile(path string) (*configFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Settings
+	if filepath.Ext(path) == ".json" {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&s)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&s)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("docnametypo config %s: %w", path, err)
+	}
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("docnametypo config %s: %w", path, err)
+	}
+	for i := range s.Overrides {
+		s.Overrides[i].dir = dir
+	}
+	return &configFile{path: path, settings: s}, nil
+}
+
+// passConfig resolves the configuration for a pass. Values from the project
+// configuration file override base, flags set explicitly on the analyzer
+// override the file, and the overrides matching the package apply last.
+// Without a configuration file, only the overrides are applied to current.
+// The flags in flags that explicit recorded as set are the explicit ones.
+func passConfig(pass *analysis.Pass, base, current Config, flags *flag.FlagSet, explicit *explicitFlags, loader *configLoader) (Config, error) {
+	var (
+		cf  *configFile
+		err error
+	)
+	if current.ConfigFile != "" {
+		cf, err = loader.load(current.ConfigFile)
+	} else if dir := packageDir(pass); dir != "" {
+		cf, err = loader.find(dir)
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	if cf == nil {
+		return applyOverrides(pass, current)
+	}
+
+	conf := base
+	conf.Overrides = nil
+	if err := cf.settings.Apply(&conf); err != nil {
+		return Config{}, fmt.Errorf("docnametypo config %s: %w", cf.path, err)
+	}
+	conf.Overrides = append(conf.Overrides, base.Overrides...)
+	fs := flag.NewFlagSet("docnametypo", flag.ContinueOnError)
+	registerFlags(fs, &conf)
+	explicit.visit(flags, func(f *flag.Flag) {
+		if err == nil {
+			err = fs.Set(f.Name, f.Value.String())
+		}
+	})
+	if err != nil {
+		return Config{}, err
+	}
+	if conf, err = applyOverrides(pass, conf); err != nil {
+		return Config{}, fmt.Errorf("docnametypo config %s: %w", cf.path, err)
+	}
+	return conf, nil
+}
+
+// packageDir returns the directory holding the files of the pass.
+func packageDir(pass *analysis.Pass) string {
+	for _, f := range pass.Files {
+		if tf := pass.Fset.File(f.Pos()); tf != nil && tf.Name() != "" {
+			if dir, err := filepath.Abs(filepath.Dir(tf.Name())); err == nil {
+				return dir
+			}
+		}
+	}
+	return ""
+}
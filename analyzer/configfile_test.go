@@ -0,0 +1,74 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	cf, err := readConfigFile(write(".docnametypo.yaml", "maxdist: 2\nallowed-prefixes: op,ui\n"))
+	if err != nil {
+		t.Fatalf("readConfigFile: %v", err)
+	}
+	cfg := DefaultConfig()
+	if err := cf.settings.Apply(&cfg); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if cfg.MaxDist != 2 || cfg.AllowedPrefixes != "op,ui" || !cfg.IncludeUnexported {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+
+	if _, err := readConfigFile(write("empty.yaml", "")); err != nil {
+		t.Fatalf("empty file: %v", err)
+	}
+
+	for name, content := range map[string]string{
+		"unknown.yaml": "max-dist: 2\n",
+		"unknown.json": `{"include-exports": true}`,
+	} {
+		_, err := readConfigFile(write(name, content))
+		if err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("readConfigFile(%s) error = %v, want error naming the file", name, err)
+		}
+	}
+}
+
+func TestConfigLoaderStopsAtModuleRoot(t *testing.T) {
+	root := t.TempDir()
+	module := filepath.Join(root, "module")
+	pkg := filepath.Join(module, "internal", "pkg")
+	if err := os.MkdirAll(pkg, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".docnametypo.yaml"), []byte("maxdist: 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(module, "go.mod"), []byte("module example.com/m\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := newConfigLoader()
+	if cf, err := loader.find(pkg); err != nil || cf != nil {
+		t.Fatalf("find(%s) = %v, %v; want no config outside the module", pkg, cf, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(module, ".docnametypo.json"), []byte(`{"maxdist": 3}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loader = newConfigLoader()
+	cf, err := loader.find(pkg)
+	if err != nil || cf == nil || *cf.settings.MaxDist != 3 {
+		t.Fatalf("find(%s) = %v, %v; want the module config", pkg, cf, err)
+	}
+}
@@ -0,0 +1,267 @@
+package analyzer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// lineRange is an inclusive range of 1-based line numbers.
+type lineRange struct {
+	start, end int
+}
+
+// changedLines maps absolute file paths to the new-side lines a diff touches.
+// A nil changedLines keeps every finding.
+type changedLines map[string][]lineRange
+
+// touches reports whether any line from..to of file was changed.
+func (cl changedLines) touches(file string, from, to int) bool {
+	if cl == nil {
+		return true
+	}
+	ranges, ok := cl[filepath.Clean(file)]
+	if !ok {
+		// git reports paths below the resolved repository root.
+		if resolved, err := filepath.EvalSymlinks(file); err == nil {
+			ranges = cl[resolved]
+		}
+	}
+	for _, r := range ranges {
+		if r.start <= to && from <= r.end {
+			return true
+		}
+	}
+	return false
+}
+
+// covers reports whether the lines spanned by pos..end were changed.
+func (cl changedLines) covers(fset *token.FileSet, pos, end token.Pos) bool {
+	if cl == nil {
+		return true
+	}
+	tf := fset.File(pos)
+	if tf == nil {
+		return false
+	}
+	to := tf.Line(pos)
+	if end.IsValid() && end > pos {
+		to = tf.Line(end)
+	}
+	return cl.touches(tf.Name(), tf.Line(pos), to)
+}
+
+// parseUnifiedDiff collects the new-side lines of each hunk in a unified
+// diff. Relative paths are resolved against root, and a leading "b/" as
+// written by git is removed. A hunk that only deletes lines marks the line
+// before the deletion, so removing part of a doc comment still counts as
+// touching it.
+func parseUnifiedDiff(r io.Reader, root string) (changedLines, error) {
+	cl := changedLines{}
+	var file string
+	// oldLeft and newLeft count the hunk body lines still to come, so body
+	// lines that look like headers are not mistaken for them.
+	var oldLeft, newLeft int
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+			case strings.HasPrefix(line, " "), line == "":
+				oldLeft--
+				newLeft--
+			}
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			name, _, _ := strings.Cut(strings.TrimPrefix(line, "+++ "), "\t")
+			if name == "/dev/null" {
+				file = ""
+				continue
+			}
+			name = strings.TrimPrefix(name, "b/")
+			if !filepath.IsAbs(name) {
+				name = filepath.Join(root, name)
+			}
+			file = filepath.Clean(name)
+		case strings.HasPrefix(line, "@@ "):
+			oldCount, start, count, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, err
+			}
+			oldLeft, newLeft = oldCount, count
+			if file == "" {
+				continue
+			}
+			if count == 0 {
+				start, count = max(start, 1), 1
+			}
+			cl[file] = append(cl[file], lineRange{start: start, end: start + count - 1})
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return cl, nil
+}
+
+// parseHunkHeader returns the old-side length and the new-side start and
+// length of a hunk header such as "@@ -12,3 +14,5 @@".
+func parseHunkHeader(line string) (oldCount, start, count int, err error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q", line)
+	}
+	_, oldCount, err = parseHunkRange(fields[1][1:])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q", line)
+	}
+	start, count, err = parseHunkRange(fields[2][1:])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q", line)
+	}
+	return oldCount, start, count, nil
+}
+
+// parseHunkRange parses "start,count" or "start", whose count is 1.
+func parseHunkRange(s string) (start, count int, err error) {
+	startText, countText, hasCount := strings.Cut(s, ",")
+	if start, err = strconv.Atoi(startText); err != nil {
+		return 0, 0, err
+	}
+	count = 1
+	if hasCount {
+		if count, err = strconv.Atoi(countText); err != nil {
+			return 0, 0, err
+		}
+	}
+	return start, count, nil
+}
+
+// diffStore computes the changed lines for -new-from-rev and -new-from-patch
+// once per repository or patch file and shares them across passes.
+type diffStore struct {
+	mu     sync.Mutex
+	loaded map[string]loadedDiff
+}
+
+type loadedDiff struct {
+	lines changedLines
+	err   error
+}
+
+func newDiffStore() *diffStore {
+	return &diffStore{loaded: make(map[string]loadedDiff)}
+}
+
+// changes returns the lines to keep findings for, or nil when neither
+// -new-from-rev nor -new-from-patch is set.
+func (s *diffStore) changes(pass *analysis.Pass, cfg Config) (changedLines, error) {
+	switch {
+	case cfg.NewFromRev != "" && cfg.NewFromPatch != "":
+		return nil, errors.New("docnametypo: -new-from-rev and -new-from-patch cannot be used together")
+	case cfg.NewFromPatch != "":
+		path, err := filepath.Abs(cfg.NewFromPatch)
+		if err != nil {
+			return nil, fmt.Errorf("docnametypo -new-from-patch: %w", err)
+		}
+		return s.load("patch\x00"+path, func() (changedLines, error) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("docnametypo -new-from-patch: %w", err)
+			}
+			wd, err := os.Getwd()
+			if err != nil {
+				return nil, fmt.Errorf("docnametypo -new-from-patch: %w", err)
+			}
+			lines, err := parseUnifiedDiff(bytes.NewReader(data), wd)
+			if err != nil {
+				return nil, fmt.Errorf("docnametypo -new-from-patch %s: %w", cfg.NewFromPatch, err)
+			}
+			return lines, nil
+		})
+	case cfg.NewFromRev != "":
+		top, err := git(packageDir(pass), "rev-parse", "--show-toplevel")
+		if err != nil {
+			return nil, fmt.Errorf("docnametypo -new-from-rev: %w", err)
+		}
+		top = strings.TrimSpace(top)
+		return s.load("rev\x00"+cfg.NewFromRev+"\x00"+top, func() (changedLines, error) {
+			lines, err := gitChangedLines(top, cfg.NewFromRev)
+			if err != nil {
+				return nil, fmt.Errorf("docnametypo -new-from-rev %s: %w", cfg.NewFromRev, err)
+			}
+			return lines, nil
+		})
+	}
+	return nil, nil
+}
+
+func (s *diffStore) load(key string, compute func() (changedLines, error)) (changedLines, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ld, ok := s.loaded[key]; ok {
+		return ld.lines, ld.err
+	}
+	lines, err := compute()
+	s.loaded[key] = loadedDiff{lines: lines, err: err}
+	return lines, err
+}
+
+// gitChangedLines diffs the working tree of the repository at top against
+// rev. Untracked files count as changed throughout.
+func gitChangedLines(top, rev string) (changedLines, error) {
+	out, err := git(top, "diff", "--no-color", "--no-ext-diff", "--unified=0", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+	lines, err := parseUnifiedDiff(strings.NewReader(out), top)
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := git(top, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range strings.Split(untracked, "\x00") {
+		if name != "" {
+			file := filepath.Join(top, name)
+			lines[file] = append(lines[file], lineRange{start: 1, end: math.MaxInt})
+		}
+	}
+	return lines, nil
+}
+
+// git runs the local git binary in dir and returns its standard output.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, msg)
+		}
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
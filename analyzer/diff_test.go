@@ -0,0 +1,165 @@
+package analyzer
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestParseUnifiedDiff(t *testing.T) {
+	diff := `diff --git a/pkg/a.go b/pkg/a.go
+--- a/pkg/a.go
++++ b/pkg/a.go
+@@ -3,2 +3,3 @@ func a() {
+-old
+-old
+++++ added line that looks like a header
++new
++new
+@@ -20 +21,0 @@
+-removed
+diff --git a/gone.go b/gone.go
+--- a/gone.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package gone
+-
+--- /dev/null
++++ /abs/b.go	2024-01-01 00:00:00
+@@ -0,0 +1 @@
++package b
+`
+	got, err := parseUnifiedDiff(strings.NewReader(diff), "/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := changedLines{
+		"/repo/pkg/a.go": {{3, 5}, {21, 21}},
+		"/abs/b.go":      {{1, 1}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseUnifiedDiff = %v, want %v", got, want)
+	}
+
+	for _, tt := range []struct {
+		file     string
+		from, to int
+		want     bool
+	}{
+		{"/repo/pkg/a.go", 1, 2, false},
+		{"/repo/pkg/a.go", 1, 3, true},
+		{"/repo/pkg/a.go", 5, 5, true},
+		{"/repo/pkg/a.go", 6, 20, false},
+		{"/repo/pkg/a.go", 21, 21, true},
+		{"/repo/other.go", 1, 100, false},
+	} {
+		if got := got.touches(tt.file, tt.from, tt.to); got != tt.want {
+			t.Errorf("touches(%s, %d, %d) = %v, want %v", tt.file, tt.from, tt.to, got, tt.want)
+		}
+	}
+	if !changedLines(nil).touches("/any.go", 1, 1) {
+		t.Errorf("nil changedLines should keep every line")
+	}
+
+	if _, err := parseUnifiedDiff(strings.NewReader("+++ b/x.go\n@@ bogus @@\n"), "/repo"); err == nil {
+		t.Errorf("expected error for malformed hunk header")
+	}
+}
+
+func TestNewFromPatch(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.NewFromPatch = filepath.Join(analysistest.TestData(), "diffonly.patch")
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(cfg), "diffonly")
+}
+
+// gitTestRepo is a git repository laid out as an analysistest GOPATH with a
+// single package.
+type gitTestRepo struct {
+	t      *testing.T
+	dir    string
+	pkgDir string
+}
+
+func newGitTestRepo(t *testing.T, pkg string) *gitTestRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	pkgDir := filepath.Join(dir, "src", pkg)
+	if err := os.MkdirAll(pkgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	r := &gitTestRepo{t: t, dir: dir, pkgDir: pkgDir}
+	r.git("init", "-q")
+	return r
+}
+
+func (r *gitTestRepo) write(name, content string) {
+	r.t.Helper()
+	if err := os.WriteFile(filepath.Join(r.pkgDir, name), []byte(content), 0o644); err != nil {
+		r.t.Fatal(err)
+	}
+}
+
+func (r *gitTestRepo) commit(msg string) {
+	r.t.Helper()
+	r.git("add", ".")
+	r.git("commit", "-q", "-m", msg)
+}
+
+func (r *gitTestRepo) git(args ...string) {
+	r.t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = r.dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		r.t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestNewFromRev(t *testing.T) {
+	repo := newGitTestRepo(t, "newfromrev")
+	repo.write("old.go", "package newfromrev\n\n// loadConfg is committed and not reported.\nfunc loadConfig() {}\n\n// saveConfg is edited later.\nfunc saveConfig() {}\n")
+	repo.commit("initial")
+
+	repo.write("old.go", "package newfromrev\n\n// loadConfg is committed and not reported.\nfunc loadConfig() {}\n\n// saveConfg is edited and reported. // want `doc comment starts with 'saveConfg' but symbol is 'saveConfig'`\nfunc saveConfig() {}\n")
+	repo.write("new.go", "package newfromrev\n\n// dropConfg is untracked and reported. // want `doc comment starts with 'dropConfg' but symbol is 'dropConfig'`\nfunc dropConfig() {}\n")
+
+	cfg := DefaultConfig()
+	cfg.NewFromRev = "HEAD"
+	analysistest.Run(t, repo.dir, NewAnalyzer(cfg), "newfromrev")
+
+	if _, err := gitChangedLines(repo.dir, "no-such-revision"); err == nil {
+		t.Errorf("expected an error for an unknown revision")
+	}
+}
+
+func TestNewFromRevWithBaseline(t *testing.T) {
+	repo := newGitTestRepo(t, "revbaseline")
+	repo.write("old.go", "package revbaseline\n\n// loadConfg is committed and in the baseline.\nfunc loadConfig() {}\n\n// saveConfg is edited later.\nfunc saveConfig() {}\n")
+	repo.commit("initial")
+
+	repo.write("old.go", "package revbaseline\n\n// loadConfg is committed and in the baseline.\nfunc loadConfig() {}\n\n// saveConfg is edited and in the baseline.\nfunc saveConfig() {}\n")
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(baseline, []byte(`{
+  "version": 1,
+  "entries": [
+    {"package": "revbaseline", "file": "old.go", "symbol": "loadConfig", "kind": "func", "token": "loadConfg"},
+    {"package": "revbaseline", "file": "old.go", "symbol": "saveConfig", "kind": "func", "token": "saveConfg"}
+  ]
+}
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Neither entry is stale: loadConfig's finding is on an unchanged line.
+	cfg := DefaultConfig()
+	cfg.NewFromRev = "HEAD"
+	cfg.Baseline = baseline
+	analysistest.Run(t, repo.dir, NewAnalyzer(cfg), "revbaseline")
+}
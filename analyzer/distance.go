@@ -1,51 +1,74 @@
 package analyzer
 
-// passesDistanceGate ensures the distance match shares enough overlap.
-func passesDistanceGate(doc, name string, dist int) bool {
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// Names of the edit distance metrics selected by -distance.
+const (
+	distanceDamerau  = "damerau-levenshtein"
+	distanceWeighted = "weighted"
+)
+
+// cheapEditCost is what weightedDistance charges for edits that are common
+// typing slips: hitting a neighbouring key, doubling or undoubling a letter,
+// and dropping a vowel.
+const cheapEditCost = 0.5
+
+// passesDistanceGate ensures the distance match shares enough overlap. Like
+// damerauLevenshtein, it measures lengths in runes. A weighted distance
+// lowers the length a token needs, but every edit, however cheap, still
+// changes a whole rune, so the overlap is checked against dist rounded up.
+func passesDistanceGate(doc, name string, dist float64) bool {
 	if dist <= 0 {
 		return false
 	}
 
-	docLen := len(doc)
-	nameLen := len(name)
-	if docLen < minDocTokenLen+dist || nameLen < minDocTokenLen {
+	docLen := utf8.RuneCountInString(doc)
+	nameLen := utf8.RuneCountInString(name)
+	if float64(docLen) < minDocTokenLen+dist || nameLen < minDocTokenLen {
 		return false
 	}
+	edits := int(math.Ceil(dist))
 
 	sharedPrefix := commonPrefixLength(doc, name)
 	sharedSuffix := commonSuffixLength(doc, name)
 	shared := min(sharedPrefix+sharedSuffix, docLen)
 
-	required := max(docLen-dist, minDocTokenLen)
+	required := max(docLen-edits, minDocTokenLen)
 	if shared >= required {
 		return true
 	}
-	return docLen >= 2*minDocTokenLen && shared*2 >= docLen && docLen-shared <= dist
+	return docLen >= 2*minDocTokenLen && shared*2 >= docLen && docLen-shared <= edits
 }
 
-// commonPrefixLength returns the length of the shared prefix.
+// commonPrefixLength returns the length of the shared prefix in runes.
 func commonPrefixLength(a, b string) int {
-	limit := min(len(a), len(b))
-	for i := range limit {
-		if a[i] != b[i] {
-			return i
+	count := 0
+	for a != "" && b != "" {
+		ra, sa := utf8.DecodeRuneInString(a)
+		rb, sb := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			break
 		}
+		count++
+		a, b = a[sa:], b[sb:]
 	}
-	return limit
+	return count
 }
 
-// commonSuffixLength returns the length of the shared suffix.
+// commonSuffixLength returns the length of the shared suffix in runes.
 func commonSuffixLength(a, b string) int {
-	ia := len(a) - 1
-	ib := len(b) - 1
 	count := 0
-	for ia >= 0 && ib >= 0 {
-		if a[ia] != b[ib] {
+	for a != "" && b != "" {
+		ra, sa := utf8.DecodeLastRuneInString(a)
+		rb, sb := utf8.DecodeLastRuneInString(b)
+		if ra != rb {
 			break
 		}
 		count++
-		ia--
-		ib--
+		a, b = a[:len(a)-sa], b[:len(b)-sb]
 	}
 	return count
 }
@@ -92,6 +115,108 @@ func damerauLevenshtein(a, b string) int {
 	return d[na][nb]
 }
 
+// weightedDistance is damerauLevenshtein with cheapEditCost charged for
+// substituting a QWERTY neighbour, for inserting or deleting a letter next to
+// the same letter, and for inserting or deleting a vowel, so procesd is
+// closer to processs than two unrelated letters would make it. Transpositions
+// and all other edits cost 1.
+func weightedDistance(a, b string) float64 {
+	ra := []rune(a)
+	rb := []rune(b)
+	na := len(ra)
+	nb := len(rb)
+	d := make([][]float64, na+1)
+	for i := range d {
+		d[i] = make([]float64, nb+1)
+	}
+	for i := 1; i <= na; i++ {
+		d[i][0] = d[i-1][0] + indelCost(ra, i-1)
+	}
+	for j := 1; j <= nb; j++ {
+		d[0][j] = d[0][j-1] + indelCost(rb, j-1)
+	}
+
+	for i := 1; i <= na; i++ {
+		for j := 1; j <= nb; j++ {
+			del := d[i-1][j] + indelCost(ra, i-1)
+			ins := d[i][j-1] + indelCost(rb, j-1)
+			sub := d[i-1][j-1] + substitutionCost(ra[i-1], rb[j-1])
+			v := min(del, ins, sub)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				v = min(v, d[i-2][j-2]+1)
+			}
+			d[i][j] = v
+		}
+	}
+	return d[na][nb]
+}
+
+// indelCost is the weighted cost of inserting or deleting s[i].
+func indelCost(s []rune, i int) float64 {
+	r := s[i]
+	if isVowel(r) || i > 0 && s[i-1] == r || i+1 < len(s) && s[i+1] == r {
+		return cheapEditCost
+	}
+	return 1
+}
+
+// substitutionCost is the weighted cost of replacing a with b.
+func substitutionCost(a, b rune) float64 {
+	switch {
+	case a == b:
+		return 0
+	case keyNeighbours[a][b]:
+		return cheapEditCost
+	}
+	return 1
+}
+
+// isVowel reports whether r is a lowercase ASCII vowel.
+func isVowel(r rune) bool {
+	switch r {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	}
+	return false
+}
+
+// keyNeighbours maps each lowercase letter to the letters next to it on a
+// QWERTY keyboard, in its own row and the rows above and below.
+var keyNeighbours = buildKeyNeighbours([]string{"qwertyuiop", "asdfghjkl", "zxcvbnm"})
+
+// buildKeyNeighbours computes keyNeighbours from the keyboard rows, each
+// offset by about half a key to the right of the one above, so that the key
+// at column i touches columns i and i+1 of the row above.
+func buildKeyNeighbours(rows []string) map[rune]map[rune]bool {
+	neighbours := make(map[rune]map[rune]bool)
+	link := func(a, b byte) {
+		for _, p := range [][2]rune{{rune(a), rune(b)}, {rune(b), rune(a)}} {
+			if neighbours[p[0]] == nil {
+				neighbours[p[0]] = make(map[rune]bool)
+			}
+			neighbours[p[0]][p[1]] = true
+		}
+	}
+	for r, row := range rows {
+		for i := range len(row) {
+			if i+1 < len(row) {
+				link(row[i], row[i+1])
+			}
+			if r == 0 {
+				continue
+			}
+			above := rows[r-1]
+			for _, k := range []int{i, i + 1} {
+				if k < len(above) {
+					link(row[i], above[k])
+				}
+			}
+		}
+	}
+	return neighbours
+}
+
 func abs(x int) int {
 	if x < 0 {
 		return -x
@@ -5,15 +5,79 @@ import "testing"
 func TestPassesDistanceGate(t *testing.T) {
 	tests := []struct {
 		doc, sym string
-		dist     int
+		dist     float64
 		want     bool
 	}{
 		{"validateAllowedTopology", "validateAllowedTopologies", 2, true},
 		{"validateAllowedTopology", "Foo", 2, false},
+		// Lengths and shared affixes are counted in runes, like the distance.
+		{"σύνολα", "σύνολο", 1, true},
+		{"größe", "grüße", 1, true},
+		// A cheap weighted edit lets a shorter token through, but the
+		// overlap is still checked against whole runes.
+		{"rendr", "render", 0.5, true},
+		{"rendr", "render", 2.5, false},
+		{"synchrnze", "synchronize", 1, true},
 	}
 	for _, tt := range tests {
 		if got := passesDistanceGate(tt.doc, tt.sym, tt.dist); got != tt.want {
-			t.Errorf("passesDistanceGate(%q,%q,%d)=%v, want %v", tt.doc, tt.sym, tt.dist, got, tt.want)
+			t.Errorf("passesDistanceGate(%q,%q,%g)=%v, want %v", tt.doc, tt.sym, tt.dist, got, tt.want)
+		}
+	}
+}
+
+func TestCommonAffixLengthCountsRunes(t *testing.T) {
+	if got := commonPrefixLength("größeBerechnen", "größeWert"); got != 5 {
+		t.Errorf("commonPrefixLength = %d, want 5", got)
+	}
+	// "ö" and "ü" share their first UTF-8 byte, and "ß" is two bytes.
+	if got := commonPrefixLength("größe", "grüße"); got != 2 {
+		t.Errorf("commonPrefixLength = %d, want 2", got)
+	}
+	if got := commonSuffixLength("größe", "grüße"); got != 2 {
+		t.Errorf("commonSuffixLength = %d, want 2", got)
+	}
+	if got := commonPrefixLength("ö", "ü"); got != 0 {
+		t.Errorf("commonPrefixLength(ö, ü) = %d, want 0", got)
+	}
+}
+
+func TestWeightedDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"process", "process", 0},
+		// Neighbouring keys and a doubled letter.
+		{"procesd", "processs", 1},
+		// Dropped vowels.
+		{"synchrnze", "synchronize", 1},
+		{"rendr", "render", 0.5},
+		// Keys that are not neighbours cost a full edit.
+		{"martial", "marshal", 2},
+		{"flsuh", "flush", 1},
+		{"", "abc", 2.5},
+	}
+	for _, tt := range tests {
+		if got := weightedDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("weightedDistance(%q,%q)=%g, want %g", tt.a, tt.b, got, tt.want)
+		}
+		if got := weightedDistance(tt.b, tt.a); got != tt.want {
+			t.Errorf("weightedDistance(%q,%q)=%g, want %g", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestKeyNeighbours(t *testing.T) {
+	for _, pair := range []string{"qw", "qa", "sd", "sz", "sx", "ws", "gb", "mk", "pl", "az"} {
+		a, b := rune(pair[0]), rune(pair[1])
+		if !keyNeighbours[a][b] || !keyNeighbours[b][a] {
+			t.Errorf("%c and %c should be neighbours", a, b)
+		}
+	}
+	for _, pair := range []string{"qs", "zd", "pm", "ml", "ts", "dh"} {
+		if a, b := rune(pair[0]), rune(pair[1]); keyNeighbours[a][b] {
+			t.Errorf("%c and %c should not be neighbours", a, b)
 		}
 	}
 }
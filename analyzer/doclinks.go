@@ -0,0 +1,427 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// docLinkRE matches the bracketed text of a candidate doc link. Whether it
+// is one is decided by parseDocLink, following go/doc/comment.
+var docLinkRE = regexp.MustCompile(`\[([^\[\]\s]+)\]`)
+
+// linkDefRE matches a link definition line such as "[Text]: https://...".
+var linkDefRE = regexp.MustCompile(`^\[([^\]]+)\]:\s*\S`)
+
+// docLink is a doc link in a comment, split as go/doc/comment splits it:
+// [pkg.Recv.Name], where pkg and Recv may be empty.
+type docLink struct {
+	text           string
+	pkg, recv      string
+	name           string
+	start          token.Pos // position of text, after the '[' and any '*'
+	recvAt, nameAt int       // offsets of recv and name in text
+}
+
+// documented is a doc comment together with the declaration it documents.
+type documented struct {
+	doc     *ast.CommentGroup
+	name    string
+	kind    symbolKind
+	declPos token.Pos
+	// ifaceMethod marks the comments of interface methods, which are
+	// kindField in the syntax but checked under -include-interface-methods.
+	ifaceMethod bool
+}
+
+// checkDocLinks reports doc links in the doc comments of file that do not
+// resolve to a declaration, suggesting the closest name that does. Only the
+// comments of declarations the -include-* settings select are checked. Links
+// to packages the pass does not import cannot be verified and are skipped, as
+// are all links when the pass has no type information.
+func (c *checker) checkDocLinks(file *ast.File) {
+	if !c.hasTypes() {
+		return
+	}
+	imports := c.fileImports(file)
+	for _, d := range documentedComments(file) {
+		links := docLinks(d.doc)
+		if len(links) == 0 {
+			continue
+		}
+		tr := c.startTrace(d.name, d.kind, d.declPos)
+		if reason := c.excludes(d); reason != "" {
+			tr.step("doc links not checked: %s", reason)
+			tr.flush()
+			continue
+		}
+		for _, l := range links {
+			c.checkDocLink(d, l, imports, tr)
+		}
+		tr.flush()
+	}
+}
+
+// checkDocLink resolves one doc link and reports it if it does not resolve.
+func (c *checker) checkDocLink(d documented, l docLink, imports map[string]*types.Package, tr *declTrace) {
+	scope := c.pass.Pkg.Scope()
+	if l.pkg != "" {
+		pkg := imports[l.pkg]
+		if pkg == nil {
+			tr.step("doc link [%s]: package %q is not imported, not checked", l.text, l.pkg)
+			return
+		}
+		scope = pkg.Scope()
+	}
+
+	// bad is the part of the link that does not resolve, at offset at in
+	// l.text; candidates are the names that could take its place.
+	var bad string
+	var at int
+	var candidates []string
+	if l.recv == "" {
+		if scope.Lookup(l.name) != nil {
+			return
+		}
+		bad, at, candidates = l.name, l.nameAt, exportedScopeNames(scope)
+	} else {
+		tn, ok := scope.Lookup(l.recv).(*types.TypeName)
+		if !ok {
+			bad, at, candidates = l.recv, l.recvAt, exportedTypeNames(scope)
+		} else {
+			if obj, _, _ := types.LookupFieldOrMethod(tn.Type(), true, tn.Pkg(), l.name); obj != nil {
+				return
+			}
+			bad, at, candidates = l.name, l.nameAt, exportedNames(typeMembers(tn.Type()))
+		}
+	}
+	tr.step("doc link [%s]: '%s' does not resolve", l.text, bad)
+
+	start := l.start + token.Pos(at)
+	f := finding{doc: d.doc, name: d.name, kind: d.kind, docTok: l.text, tokStart: l.start, tokEnd: l.start + token.Pos(len(l.text)), declPos: d.declPos, trace: tr, confidence: 1}
+	diag := analysis.Diagnostic{
+		Pos:      l.start,
+		End:      f.tokEnd,
+		Category: categoryBrokenDocLink,
+		Message:  "doc link [" + l.text + "] does not resolve",
+	}
+	if best, m, ok := closestName(c.cfg, bad, candidates, nil); ok {
+		tr.step("doc link [%s]: '%s' matched by %s", l.text, best, m)
+		fixed := l.text[:at] + best + l.text[at+len(bad):]
+		diag.Message += "; did you mean [" + fixed + "]?"
+		if c.cfg.Explain {
+			diag.Message += " [" + m.String() + "]"
+		}
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   "replace doc link with closest declared name",
+			TextEdits: []analysis.TextEdit{{Pos: start, End: start + token.Pos(len(bad)), NewText: []byte(best)}},
+		}}
+	}
+	c.report(f, diag)
+}
+
+// closestName returns the candidate that the similarity heuristics tie to
+// tok, preferring the smallest edit distance and then the longest shared
+// prefix and suffix. A non-nil keep limits the heuristics that count.
+func closestName(cfg matchConfig, tok string, candidates []string, keep func(match) bool) (string, match, bool) {
+	var best string
+	var bestMatch match
+	bestDist, bestShared := -1.0, 0
+	tokLower := strings.ToLower(tok)
+	for _, cand := range candidates {
+		m, ok := matchDocToken(cfg, tok, cand)
+		if !ok || keep != nil && !keep(m) {
+			continue
+		}
+		candLower := strings.ToLower(cand)
+		d := cfg.distance(tokLower, candLower)
+		shared := commonPrefixLength(tokLower, candLower) + commonSuffixLength(tokLower, candLower)
+		if bestDist < 0 || d < bestDist || d == bestDist && (shared > bestShared || shared == bestShared && cand < best) {
+			best, bestMatch, bestDist, bestShared = cand, m, d, shared
+		}
+	}
+	return best, bestMatch, bestDist >= 0
+}
+
+// exportedScopeNames returns the exported names declared in scope.
+func exportedScopeNames(scope *types.Scope) []string {
+	return exportedNames(scope.Names())
+}
+
+// exportedNames returns the exported names in names.
+func exportedNames(names []string) []string {
+	var exported []string
+	for _, name := range names {
+		if ast.IsExported(name) {
+			exported = append(exported, name)
+		}
+	}
+	return exported
+}
+
+// exportedTypeNames returns the exported type names declared in scope.
+func exportedTypeNames(scope *types.Scope) []string {
+	var names []string
+	for _, name := range scope.Names() {
+		if _, ok := scope.Lookup(name).(*types.TypeName); ok && ast.IsExported(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// typeMembers returns the names of the methods of T and *T and of the
+// fields of T.
+func typeMembers(T types.Type) []string {
+	var names []string
+	mset := types.NewMethodSet(types.NewPointer(T))
+	for i := range mset.Len() {
+		names = append(names, mset.At(i).Obj().Name())
+	}
+	if st, ok := T.Underlying().(*types.Struct); ok {
+		for i := range st.NumFields() {
+			names = append(names, st.Field(i).Name())
+		}
+	}
+	return names
+}
+
+// hasTypes reports whether the pass carries type information. Drivers that
+// load packages in syntax mode leave the package scope and TypesInfo empty,
+// so nothing would seem to be declared.
+func (c *checker) hasTypes() bool {
+	info := c.pass.TypesInfo
+	return c.pass.Pkg != nil && info != nil && len(info.Defs) > 0
+}
+
+// fileImports maps the names a doc link may use for the packages file
+// imports, both the local package name and the import path, to the package.
+func (c *checker) fileImports(file *ast.File) map[string]*types.Package {
+	imports := make(map[string]*types.Package)
+	if c.pass.TypesInfo == nil {
+		return imports
+	}
+	for _, spec := range file.Imports {
+		pn := c.pass.TypesInfo.PkgNameOf(spec)
+		if pn == nil {
+			continue
+		}
+		imports[pn.Imported().Path()] = pn.Imported()
+		if pn.Name() != "_" && pn.Name() != "." {
+			imports[pn.Name()] = pn.Imported()
+		}
+	}
+	return imports
+}
+
+// excludes returns why the -include-* settings leave out the declaration d
+// documents, or "" when its comment is checked.
+func (c *checker) excludes(d documented) string {
+	cfg := c.cfg
+	switch {
+	case d.kind == kindPackage:
+		if !cfg.IncludePackageDoc {
+			return "package doc comments are not checked"
+		}
+		return ""
+	case d.ifaceMethod:
+		if !cfg.IncludeInterfaceMethods {
+			return "interface methods are not checked"
+		}
+	case d.kind == kindType && !cfg.IncludeTypes:
+		return "type declarations are not checked"
+	case d.kind == kindValue && !cfg.IncludeValues:
+		return "const and var declarations are not checked"
+	case d.kind == kindField && !cfg.IncludeFields:
+		return "struct fields are not checked"
+	}
+	if ast.IsExported(d.name) {
+		if !cfg.IncludeExported {
+			return "exported declarations are not checked"
+		}
+	} else if !cfg.IncludeUnexported {
+		return "unexported declarations are not checked"
+	}
+	return ""
+}
+
+// documentedComments returns the doc comments in file with the declaration
+// each one documents.
+func documentedComments(file *ast.File) []documented {
+	var docs []documented
+	seen := make(map[*ast.CommentGroup]bool)
+	add := func(doc *ast.CommentGroup, id *ast.Ident, kind symbolKind) *documented {
+		if doc == nil || id == nil || seen[doc] {
+			return nil
+		}
+		seen[doc] = true
+		docs = append(docs, documented{doc: doc, name: id.Name, kind: kind, declPos: id.Pos()})
+		return &docs[len(docs)-1]
+	}
+	add(file.Doc, file.Name, kindPackage)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.InterfaceType:
+			// Add the methods first, before they are visited as fields.
+			for _, m := range n.Methods.List {
+				id := baseTypeIdent(m.Type)
+				if len(m.Names) > 0 {
+					id = m.Names[0]
+				}
+				for _, doc := range []*ast.CommentGroup{m.Doc, m.Comment} {
+					if d := add(doc, id, kindField); d != nil {
+						d.ifaceMethod = true
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			add(n.Doc, n.Name, kindFunc)
+		case *ast.GenDecl:
+			if len(n.Specs) > 0 {
+				switch sp := n.Specs[0].(type) {
+				case *ast.TypeSpec:
+					add(n.Doc, sp.Name, kindType)
+				case *ast.ValueSpec:
+					add(n.Doc, sp.Names[0], kindValue)
+				}
+			}
+		case *ast.TypeSpec:
+			add(n.Doc, n.Name, kindType)
+		case *ast.ValueSpec:
+			add(n.Doc, n.Names[0], kindValue)
+		case *ast.Field:
+			id := baseTypeIdent(n.Type)
+			if len(n.Names) > 0 {
+				id = n.Names[0]
+			}
+			add(n.Doc, id, kindField)
+			add(n.Comment, id, kindField)
+		}
+		return true
+	})
+	return docs
+}
+
+// docLinks returns the doc links in cg, skipping directives, code blocks and
+// the labels of link definitions.
+func docLinks(cg *ast.CommentGroup) []docLink {
+	type line struct {
+		text string
+		pos  token.Pos
+	}
+	var lines []line
+	defined := make(map[string]bool)
+	for _, comment := range cg.List {
+		if isDirectiveComment(comment.Text) {
+			continue
+		}
+		text, offset := comment.Text, 0
+		if strings.HasPrefix(text, "/*") {
+			text, offset = strings.TrimSuffix(text[2:], "*/"), 2
+		} else {
+			text, offset = text[2:], 2
+		}
+		for _, l := range strings.SplitAfter(text, "\n") {
+			pos := comment.Slash + token.Pos(offset)
+			offset += len(l)
+			l = strings.TrimRight(l, "\r\n")
+			// Indented lines are code blocks, where links are not expanded.
+			if strings.HasPrefix(l, "\t") || strings.HasPrefix(l, "  ") {
+				continue
+			}
+			if m := linkDefRE.FindStringSubmatch(strings.TrimSpace(l)); m != nil {
+				defined[m[1]] = true
+				continue
+			}
+			lines = append(lines, line{l, pos})
+		}
+	}
+
+	var links []docLink
+	for _, l := range lines {
+		for _, m := range docLinkRE.FindAllStringSubmatchIndex(l.text, -1) {
+			before, after := l.text[:m[0]], l.text[m[1]:]
+			text := l.text[m[2]:m[3]]
+			if defined[text] || !linkBoundary(before, true) || !linkBoundary(after, false) {
+				continue
+			}
+			link, ok := parseDocLink(text)
+			if !ok {
+				continue
+			}
+			link.start = l.pos + token.Pos(m[2]+len(text)-len(link.text))
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// linkBoundary reports whether s, the text before or after a bracketed
+// link, allows the link: it must be empty or meet it at a space or
+// punctuation.
+func linkBoundary(s string, before bool) bool {
+	if s == "" {
+		return true
+	}
+	var r rune
+	if before {
+		r, _ = utf8.DecodeLastRuneInString(s)
+	} else {
+		r, _ = utf8.DecodeRuneInString(s)
+	}
+	return unicode.IsPunct(r) || r == ' ' || r == '\t'
+}
+
+// parseDocLink splits the text of a doc link into its import path or package
+// name, receiver and name. Links to a package alone are not reported.
+func parseDocLink(text string) (docLink, bool) {
+	text = strings.TrimPrefix(text, "*")
+	pkg, name, ok := splitDocName(text)
+	if !ok {
+		return docLink{}, false
+	}
+	pkg, recv, _ := splitDocName(pkg)
+	l := docLink{text: text, pkg: pkg, recv: recv, name: name, nameAt: len(text) - len(name)}
+	if recv != "" {
+		l.recvAt = l.nameAt - 1 - len(recv)
+	}
+	if pkg != "" && !isImportPath(pkg) {
+		return docLink{}, false
+	}
+	return l, true
+}
+
+// splitDocName splits text of the form before.Name, where Name is an
+// exported identifier, like go/doc/comment.
+func splitDocName(text string) (before, name string, ok bool) {
+	i := strings.LastIndex(text, ".")
+	name = text[i+1:]
+	if !token.IsIdentifier(name) || !ast.IsExported(name) {
+		return text, "", false
+	}
+	if i >= 0 {
+		before = text[:i]
+	}
+	return before, name, true
+}
+
+// isImportPath reports whether s looks like a package name or import path.
+func isImportPath(s string) bool {
+	for _, elem := range strings.Split(s, "/") {
+		if elem == "" || strings.HasPrefix(elem, ".") {
+			return false
+		}
+		for _, r := range elem {
+			if !isIdentRune(r) && r != '.' && r != '-' && r != '~' {
+				return false
+			}
+		}
+	}
+	return true
+}
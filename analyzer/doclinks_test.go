@@ -0,0 +1,32 @@
+package analyzer
+
+import "testing"
+
+func TestParseDocLink(t *testing.T) {
+	tests := []struct {
+		text            string
+		pkg, recv, name string
+		ok              bool
+	}{
+		{"Config", "", "", "Config", true},
+		{"*Server.Close", "", "Server", "Close", true},
+		{"json.Marshal", "json", "", "Marshal", true},
+		{"net/http.Handler", "net/http", "", "Handler", true},
+		{"http.Server.Close", "http", "Server", "Close", true},
+		{"example.com/mod/pkg.Type", "example.com/mod/pkg", "", "Type", true},
+		{"os", "", "", "", false},
+		{"lower", "", "", "", false},
+		{"a-b", "", "", "", false},
+		{"1", "", "", "", false},
+	}
+	for _, tt := range tests {
+		l, ok := parseDocLink(tt.text)
+		if ok != tt.ok || l.pkg != tt.pkg || l.recv != tt.recv || l.name != tt.name {
+			t.Errorf("parseDocLink(%q) = %q, %q, %q, %v; want %q, %q, %q, %v", tt.text, l.pkg, l.recv, l.name, ok, tt.pkg, tt.recv, tt.name, tt.ok)
+			continue
+		}
+		if ok && (l.text[l.nameAt:] != l.name || l.recv != "" && l.text[l.recvAt:l.recvAt+len(l.recv)] != l.recv) {
+			t.Errorf("parseDocLink(%q): offsets %d, %d do not locate %q and %q", tt.text, l.recvAt, l.nameAt, l.recv, l.name)
+		}
+	}
+}
@@ -1,22 +1,126 @@
 package analyzer
 
+import (
+	"flag"
+	"sync"
+)
+
 const defaultAllowedLeadingWords = "create,creates,creating,initialize,initializes,init,configure,configures,setup,setups,start,starts,read,reads,write,writes,send,sends,generate,generates,decode,decodes,encode,encodes,marshal,marshals,unmarshal,unmarshals,apply,applies,process,processes,make,makes,build,builds,test,tests"
 
-var (
-	maxDistFlag                 = 5
-	includeUnexportedFlag       = true
-	includeExportedFlag         = false
-	includeTypesFlag            = false
-	includeGeneratedFlag        = false
-	includeInterfaceMethodsFlag = false
-	allowedLeadingWordsFlag     = defaultAllowedLeadingWords
-	allowedPrefixesFlag         = ""
-	skipPlainWordCamelFlag      = true
-	maxCamelChunkInsertFlag     = 2
-	maxCamelChunkReplaceFlag    = 2
-)
+// defaultInitialisms are the initialisms golint expects to keep a
+// consistent case.
+const defaultInitialisms = "ACL,API,ASCII,CPU,CSS,DNS,EOF,GUID,HTML,HTTP,HTTPS,ID,IP,JSON,LHS,QPS,RAM,RHS,RPC,SLA,SMTP,SQL,SSH,TCP,TLS,TTL,UDP,UI,UID,UUID,URI,URL,UTF8,VM,XML,XMPP,XSRF,XSS"
+
+// defaultAbbreviations pairs common abbreviations with the words they stand
+// for.
+const defaultAbbreviations = "cfg=config,ctx=context,msg=message,req=request,resp=response,srv=server"
 
 const (
 	minDocTokenLen   = 3
 	maxChunkDiffSize = 6
 )
+
+// registerFlags binds the analyzer flags to the fields of cfg.
+func registerFlags(fs *flag.FlagSet, cfg *Config) {
+	fs.IntVar(&cfg.MaxDist, "maxdist", cfg.MaxDist, "maximum edit distance to consider a likely typo")
+	fs.StringVar(&cfg.Distance, "distance", cfg.Distance, "edit distance metric: damerau-levenshtein, or weighted to charge less for neighbouring-key substitutions, doubled letters and dropped vowels")
+	fs.Float64Var(&cfg.MinConfidence, "min-confidence", cfg.MinConfidence, "only report findings whose confidence, from 0 to 1, is at least this")
+	fs.BoolVar(&cfg.IncludeUnexported, "include-unexported", cfg.IncludeUnexported, "check unexported declarations")
+	fs.BoolVar(&cfg.IncludeExported, "include-exported", cfg.IncludeExported, "check exported declarations (disabled by default)")
+	fs.BoolVar(&cfg.IncludeTypes, "include-types", cfg.IncludeTypes, "also check type declarations")
+	fs.BoolVar(&cfg.IncludeGenerated, "include-generated", cfg.IncludeGenerated, "check files marked as generated")
+	fs.BoolVar(&cfg.IncludeInterfaceMethods, "include-interface-methods", cfg.IncludeInterfaceMethods, "check interface method declarations")
+	fs.BoolVar(&cfg.IncludeValues, "include-values", cfg.IncludeValues, "also check const and var declarations")
+	fs.BoolVar(&cfg.IncludeFields, "include-fields", cfg.IncludeFields, "also check struct field doc and trailing comments")
+	fs.BoolVar(&cfg.IncludePackageDoc, "include-package-doc", cfg.IncludePackageDoc, "check package doc comments against the package name, or the command name for package main")
+	fs.BoolVar(&cfg.CheckDocLinks, "check-doc-links", cfg.CheckDocLinks, "report doc links such as [Name] or [pkg.Type.Method] that do not resolve, in the doc comments selected by the -include-* flags")
+	fs.BoolVar(&cfg.CheckTestNames, "check-test-names", cfg.CheckTestNames, "report Example, Test, Benchmark and Fuzz function names that refer to a misspelled identifier")
+	fs.StringVar(&cfg.AllowedLeadingWords, "allowed-leading-words", cfg.AllowedLeadingWords, "comma-separated list of leading words to ignore (treated as narrative)")
+	fs.StringVar(&cfg.AllowedPrefixes, "allowed-prefixes", cfg.AllowedPrefixes, "comma-separated list of symbol prefixes to ignore when matching doc tokens")
+	fs.StringVar(&cfg.Initialisms, "initialisms", cfg.Initialisms, "comma-separated list of initialisms whose case alone may differ between doc token and symbol")
+	fs.BoolVar(&cfg.ReportInitialisms, "report-initialisms", cfg.ReportInitialisms, "report doc tokens that differ from the symbol only in the case of an initialism, such as userId for userID")
+	fs.StringVar(&cfg.Abbreviations, "abbreviations", cfg.Abbreviations, "comma-separated abbr=word pairs whose camelCase chunks compare equal, such as cfg=config")
+	fs.BoolVar(&cfg.ReportAbbreviations, "report-abbreviations", cfg.ReportAbbreviations, "report doc tokens that differ from the symbol only by an abbreviation, such as loadConfig for loadCfg, instead of accepting them")
+	fs.BoolVar(&cfg.SkipPlainWordCamel, "skip-plain-word-camel", cfg.SkipPlainWordCamel, "skip plain leading words when the symbol looks camelCase (reduces narrative false positives)")
+	fs.IntVar(&cfg.MaxCamelChunkInsert, "max-camel-chunk-insert", cfg.MaxCamelChunkInsert, "maximum number of camelCase chunks that may be inserted or removed (detects missing words)")
+	fs.IntVar(&cfg.MaxCamelChunkReplace, "max-camel-chunk-replace", cfg.MaxCamelChunkReplace, "maximum number of camelCase chunks that may be replaced (detects word changes)")
+	fs.BoolVar(&cfg.Explain, "explain", cfg.Explain, "append the heuristic that matched, its measurements and the confidence to each diagnostic")
+	fs.BoolVar(&cfg.ReportAtDeclaration, "report-at-declaration", cfg.ReportAtDeclaration, "report findings at the declared name instead of the doc token")
+	fs.StringVar(&cfg.Trace, "trace-symbols", cfg.Trace, "print each decision step for declarations whose name matches this regular expression")
+	fs.StringVar(&cfg.ConfigFile, "config", cfg.ConfigFile, "path to a .docnametypo.yaml or .json file (default: search from each package directory up to go.mod)")
+	fs.StringVar(&cfg.Baseline, "baseline", cfg.Baseline, "path to a baseline file whose findings are not reported; stale entries are reported")
+	fs.StringVar(&cfg.BaselineWrite, "baseline-write", cfg.BaselineWrite, "record the current findings to this baseline file instead of reporting them; cannot be combined with -baseline")
+	fs.BoolVar(&cfg.GitHistory, "git-history", cfg.GitHistory, "report doc tokens that git history shows were the symbol's previous name")
+	fs.IntVar(&cfg.GitHistoryDepth, "git-history-depth", cfg.GitHistoryDepth, "number of recent commits of each file inspected by -git-history")
+	fs.StringVar(&cfg.NewFromRev, "new-from-rev", cfg.NewFromRev, "only report findings on lines changed since this git revision")
+	fs.StringVar(&cfg.NewFromPatch, "new-from-patch", cfg.NewFromPatch, "only report findings on lines changed by this unified diff file")
+	fs.BoolVar(&cfg.ReportUnusedIgnores, "report-unused-ignores", cfg.ReportUnusedIgnores, "report docnametypo:ignore and nolint:docnametypo directives that suppress nothing")
+}
+
+// explicitFlags records which analyzer flags were set. Drivers such as
+// singlechecker register the flag values on flag sets of their own, so the
+// analyzer's FlagSet cannot tell which flags were given.
+type explicitFlags struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// track wraps each value in fs so that setting it is recorded in e.
+func (e *explicitFlags) track(fs *flag.FlagSet) {
+	fs.VisitAll(func(f *flag.Flag) {
+		f.Value = trackedValue{Value: f.Value, name: f.Name, explicit: e}
+	})
+}
+
+// visit calls fn, in lexical order, for each flag in fs that was set.
+func (e *explicitFlags) visit(fs *flag.FlagSet, fn func(*flag.Flag)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	fs.VisitAll(func(f *flag.Flag) {
+		if e.seen[f.Name] {
+			fn(f)
+		}
+	})
+}
+
+// trackedValue is a flag value that records when it is set.
+type trackedValue struct {
+	flag.Value
+	name     string
+	explicit *explicitFlags
+}
+
+func (v trackedValue) Set(s string) error {
+	if err := v.Value.Set(s); err != nil {
+		return err
+	}
+	v.explicit.mu.Lock()
+	defer v.explicit.mu.Unlock()
+	if v.explicit.seen == nil {
+		v.explicit.seen = make(map[string]bool)
+	}
+	v.explicit.seen[v.name] = true
+	return nil
+}
+
+// IsBoolFlag keeps -name without a value working for boolean flags.
+// String is safe on the zero value, which flag.PrintDefaults creates to
+// tell whether a flag has its default value.
+func (v trackedValue) String() string {
+	if v.Value == nil {
+		return ""
+	}
+	return v.Value.String()
+}
+
+func (v trackedValue) IsBoolFlag() bool {
+	b, ok := v.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+func (v trackedValue) Get() any {
+	if g, ok := v.Value.(flag.Getter); ok {
+		return g.Get()
+	}
+	return v.String()
+}
@@ -142,8 +142,10 @@ func docFirstWordHasDot(line string) bool {
 }
 
 // isNarrativeVerbForm detects verbs like "Creates" when the symbol starts similarly.
+// A word spelling the name itself, as parseUrls does for parseURLs, is not a
+// verb form of it.
 func isNarrativeVerbForm(word, funcName string) bool {
-	if len(word) < 2 {
+	if len(word) < 2 || strings.EqualFold(word, funcName) {
 		return false
 	}
 	stem, ok := strings.CutSuffix(strings.ToLower(word), "s")
@@ -0,0 +1,164 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// fileVersion is the declaration index of a file as of one commit.
+type fileVersion struct {
+	commit string
+	decls  declIndex
+}
+
+type loadedHistory struct {
+	versions []fileVersion
+	err      error
+}
+
+// historyStore reads the recent versions of files from git once and shares
+// them across passes.
+type historyStore struct {
+	mu    sync.Mutex
+	files map[string]loadedHistory
+}
+
+func newHistoryStore() *historyStore {
+	return &historyStore{files: make(map[string]loadedHistory)}
+}
+
+// versions returns the declaration indexes of filename in its last depth
+// commits, newest first, following renames of the file itself.
+func (s *historyStore) versions(filename string, depth int) ([]fileVersion, error) {
+	key := filename + "\x00" + strconv.Itoa(depth)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lh, ok := s.files[key]; ok {
+		return lh.versions, lh.err
+	}
+	versions, err := readFileHistory(filename, depth)
+	s.files[key] = loadedHistory{versions: versions, err: err}
+	return versions, err
+}
+
+func readFileHistory(filename string, depth int) ([]fileVersion, error) {
+	dir := filepath.Dir(filename)
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	top = strings.TrimSpace(top)
+	out, err := git(dir, "log", "-n", strconv.Itoa(depth), "--follow", "--format=%H", "--name-only", "--", filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []fileVersion
+	var commit string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case commit == "" || isCommitHash(line):
+			// Merge commits list no paths, so a hash may follow a hash.
+			commit = line
+			continue
+		}
+		// line is the file's path, relative to the repository root, as of
+		// commit.
+		src, err := git(top, "show", commit+":"+line)
+		if err != nil {
+			return nil, err
+		}
+		if f, err := parser.ParseFile(token.NewFileSet(), line, src, parser.SkipObjectResolution); err == nil {
+			versions = append(versions, fileVersion{commit: commit, decls: newDeclIndex([]*ast.File{f})})
+		}
+		commit = ""
+	}
+	return versions, nil
+}
+
+// isCommitHash reports whether s is a full SHA-1 or SHA-256 object name.
+func isCommitHash(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	for _, r := range s {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// checkHistory reports a doc token that no heuristic matched when a recent
+// version of the file declared the token at the same position, and did not
+// yet declare the symbol's current name, meaning the symbol was renamed. The
+// position is the declaration's place among those of its scope in the file,
+// so that edits elsewhere in the file do not move it, while a name removed
+// from another part of the file does not match.
+func (c *checker) checkHistory(f finding) {
+	if !c.cfg.GitHistory || c.history == nil || !token.IsIdentifier(f.docTok) || f.docTok == f.name {
+		return
+	}
+	scope, ok := c.decls.scopeOf[f.declPos]
+	if !ok {
+		return
+	}
+	tf := c.pass.Fset.File(f.declPos)
+	if tf == nil {
+		return
+	}
+	var file *ast.File
+	for _, af := range c.pass.Files {
+		if c.pass.Fset.File(af.Pos()) == tf {
+			file = af
+			break
+		}
+	}
+	if file == nil {
+		return
+	}
+	at, ok := newDeclIndex([]*ast.File{file}).ordinal(f.declPos)
+	if !ok {
+		return
+	}
+	versions, err := c.history.versions(tf.Name(), c.cfg.GitHistoryDepth)
+	if err != nil {
+		f.trace.step("git history unavailable: %v", err)
+		return
+	}
+	for _, v := range versions {
+		order := v.decls.order[scope]
+		if at >= len(order) || order[at].Name != f.docTok {
+			continue
+		}
+		if _, both := v.decls.byScope[scope][f.name]; both {
+			continue
+		}
+		commit := v.commit[:min(len(v.commit), 7)]
+		f.trace.step("matched by %s: '%s' was declared in commit %s", ruleStaleRename, f.docTok, commit)
+		msg := fmt.Sprintf("doc comment starts with '%s', the name '%s' had in commit %s (stale name after rename)", f.docTok, f.name, commit)
+		if c.cfg.Explain {
+			msg += " [" + ruleStaleRename + "]"
+		}
+		f.confidence = staleRenameConfidence
+		c.report(f, analysis.Diagnostic{
+			Pos:            f.declPos,
+			Category:       ruleStaleRename,
+			Message:        msg,
+			SuggestedFixes: replaceTokenFixes(f.doc, f.docTok, f.tokStart, f.tokEnd, f.name),
+		})
+		return
+	}
+	f.trace.step("not in git history")
+}
@@ -0,0 +1,72 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestGitHistory(t *testing.T) {
+	repo := newGitTestRepo(t, "githistory")
+	repo.write("store.go", `package githistory
+
+// fetchUser loads a user record.
+func fetchUser() {}
+
+// encodeJSON marshals a record.
+func encodeJSON() {}
+
+// writeOutput prints a record.
+func writeOutput() {}
+
+type store struct{}
+
+// flush writes pending records.
+func (store) flush() {}
+
+// closeAll releases every handle.
+func closeAll() {}
+`)
+	repo.commit("initial")
+
+	repo.write("store.go", `package githistory
+
+// encodeJSON marshals a record.
+func encodeJSON() {}
+
+// writeOutput prints a record.
+func writeOutput() {}
+
+// flush is the name of store's method, not of this function.
+func flushAll() {}
+`)
+	repo.commit("add flushAll")
+
+	// fetchUser was renamed in a commit; flush was renamed in the working
+	// tree. encodeJSON was deleted, but writeOutput existed alongside it, so
+	// the comment merged into writeOutput is not a rename. closeAll was
+	// declared elsewhere in the file, so shutdown is not its new name.
+	repo.write("store.go", `package githistory
+
+// fetchUser loads a user record. // want `+"`"+`doc comment starts with 'fetchUser', the name 'loadAccount' had in commit [0-9a-f]{7} \(stale name after rename\)`+"`"+`
+func loadAccount() {}
+
+// encodeJSON output is printed as one line.
+func writeOutput() {}
+
+// closeAll was removed; this comment only borrows its name.
+func shutdown() {}
+
+// flush is the name of store's method, not of this function.
+func flushAll() {}
+
+type store struct{}
+
+// flush writes pending records. // want `+"`"+`doc comment starts with 'flush', the name 'sync' had in commit [0-9a-f]{7} \(stale name after rename\)`+"`"+`
+func (store) sync() {}
+`)
+
+	cfg := DefaultConfig()
+	cfg.GitHistory = true
+	analysistest.Run(t, repo.dir, NewAnalyzer(cfg), "githistory")
+}
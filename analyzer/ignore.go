@@ -0,0 +1,186 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+type directiveKind int
+
+const (
+	directiveIgnore directiveKind = iota
+	directiveFileIgnore
+	directiveNolint
+)
+
+// ignoreDirective is a single suppression comment found in a file.
+type ignoreDirective struct {
+	comment *ast.Comment
+	kind    directiveKind
+	// specific is set for nolint directives that name docnametypo explicitly,
+	// as opposed to a bare //nolint or //nolint:all.
+	specific bool
+	used     bool
+}
+
+// ignoreSet indexes the suppression directives of a package by file and line.
+type ignoreSet struct {
+	fset       *token.FileSet
+	all        []*ignoreDirective
+	fileIgnore map[*token.File][]*ignoreDirective
+	byLine     map[*token.File]map[int][]*ignoreDirective
+}
+
+// newIgnoreSet scans the comments of files for suppression directives.
+func newIgnoreSet(fset *token.FileSet, files []*ast.File) *ignoreSet {
+	s := &ignoreSet{
+		fset:       fset,
+		fileIgnore: make(map[*token.File][]*ignoreDirective),
+		byLine:     make(map[*token.File]map[int][]*ignoreDirective),
+	}
+	for _, f := range files {
+		tf := fset.File(f.Pos())
+		if tf == nil {
+			continue
+		}
+		for _, cg := range f.Comments {
+			for _, c := range cg.List {
+				d := parseIgnoreDirective(c)
+				if d == nil {
+					continue
+				}
+				s.all = append(s.all, d)
+				if d.kind == directiveFileIgnore {
+					s.fileIgnore[tf] = append(s.fileIgnore[tf], d)
+					continue
+				}
+				lines := s.byLine[tf]
+				if lines == nil {
+					lines = make(map[int][]*ignoreDirective)
+					s.byLine[tf] = lines
+				}
+				line := tf.Line(c.Pos())
+				lines[line] = append(lines[line], d)
+			}
+		}
+	}
+	return s
+}
+
+// parseIgnoreDirective recognizes //docnametypo:ignore, //docnametypo:file-ignore
+// and //nolint comments that cover docnametypo.
+func parseIgnoreDirective(c *ast.Comment) *ignoreDirective {
+	text, ok := strings.CutPrefix(c.Text, "//")
+	if !ok {
+		return nil
+	}
+	if rest, ok := strings.CutPrefix(text, "docnametypo:"); ok {
+		name, _, _ := strings.Cut(rest, " ")
+		switch strings.TrimSpace(name) {
+		case "ignore":
+			return &ignoreDirective{comment: c, kind: directiveIgnore}
+		case "file-ignore":
+			return &ignoreDirective{comment: c, kind: directiveFileIgnore}
+		}
+		return nil
+	}
+
+	rest, ok := strings.CutPrefix(text, "nolint")
+	if !ok {
+		return nil
+	}
+	if rest == "" || rest[0] == ' ' || rest[0] == '\t' {
+		return &ignoreDirective{comment: c, kind: directiveNolint}
+	}
+	list, ok := strings.CutPrefix(rest, ":")
+	if !ok {
+		return nil
+	}
+	list, _, _ = strings.Cut(list, " ")
+	for _, linter := range strings.Split(list, ",") {
+		switch strings.TrimSpace(linter) {
+		case "docnametypo":
+			return &ignoreDirective{comment: c, kind: directiveNolint, specific: true}
+		case "all":
+			return &ignoreDirective{comment: c, kind: directiveNolint}
+		}
+	}
+	return nil
+}
+
+// suppresses reports whether a directive in the doc comment, on the
+// declaration line or at file level silences a finding, marking it used.
+func (s *ignoreSet) suppresses(doc *ast.CommentGroup, declPos token.Pos) bool {
+	tf := s.fset.File(declPos)
+	if tf == nil {
+		return false
+	}
+
+	found := false
+	for _, d := range s.fileIgnore[tf] {
+		d.used = true
+		found = true
+	}
+
+	lines := s.byLine[tf]
+	mark := func(line int) {
+		for _, d := range lines[line] {
+			d.used = true
+			found = true
+		}
+	}
+	mark(tf.Line(declPos))
+	if doc != nil && s.fset.File(doc.Pos()) == tf {
+		for line := tf.Line(doc.Pos()); line <= tf.Line(doc.End()); line++ {
+			mark(line)
+		}
+	}
+	return found
+}
+
+// reportUnused flags docnametypo directives that did not silence anything.
+// Generic //nolint and //nolint:all comments are left to other linters, and
+// in diff-only mode directives on unchanged lines are not reported.
+func (s *ignoreSet) reportUnused(pass *analysis.Pass, changes changedLines) {
+	for _, d := range s.all {
+		if d.used || (d.kind == directiveNolint && !d.specific) || !changes.covers(pass.Fset, d.comment.Pos(), d.comment.End()) {
+			continue
+		}
+		directive, _, _ := strings.Cut(d.comment.Text, " ")
+		pass.Report(analysis.Diagnostic{
+			Pos:      d.comment.Pos(),
+			End:      d.comment.End(),
+			Category: categoryUnusedIgnore,
+			Message:  "unused ignore directive '" + directive + "'",
+		})
+	}
+}
+
+// isDirectiveComment reports whether a line comment is a //tool:directive
+// rather than documentation, following the go/ast convention.
+func isDirectiveComment(text string) bool {
+	rest, ok := strings.CutPrefix(text, "//")
+	if !ok || rest == "" {
+		return false
+	}
+	if strings.HasPrefix(rest, "line ") || strings.HasPrefix(rest, "extern ") || strings.HasPrefix(rest, "export ") {
+		return true
+	}
+	colon := strings.Index(rest, ":")
+	if colon <= 0 || colon+1 >= len(rest) {
+		return false
+	}
+	for i := 0; i <= colon+1; i++ {
+		if i == colon {
+			continue
+		}
+		b := rest[i]
+		if !('a' <= b && b <= 'z' || '0' <= b && b <= '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package analyzer
+
+import (
+	"go/ast"
+	"testing"
+)
+
+func TestParseIgnoreDirective(t *testing.T) {
+	tests := []struct {
+		text     string
+		want     bool
+		kind     directiveKind
+		specific bool
+	}{
+		{"//docnametypo:ignore", true, directiveIgnore, false},
+		{"//docnametypo:ignore legacy name", true, directiveIgnore, false},
+		{"//docnametypo:file-ignore", true, directiveFileIgnore, false},
+		{"//docnametypo:unknown", false, 0, false},
+		{"// docnametypo:ignore", false, 0, false},
+		{"//nolint", true, directiveNolint, false},
+		{"//nolint // reason", true, directiveNolint, false},
+		{"//nolint:all", true, directiveNolint, false},
+		{"//nolint:errcheck,docnametypo // reason", true, directiveNolint, true},
+		{"//nolint:errcheck", false, 0, false},
+		{"//nolintfoo", false, 0, false},
+	}
+	for _, tt := range tests {
+		d := parseIgnoreDirective(&ast.Comment{Text: tt.text})
+		if (d != nil) != tt.want {
+			t.Errorf("parseIgnoreDirective(%q)=%v, want directive=%v", tt.text, d, tt.want)
+			continue
+		}
+		if d != nil && (d.kind != tt.kind || d.specific != tt.specific) {
+			t.Errorf("parseIgnoreDirective(%q)=(kind %d, specific %v), want (kind %d, specific %v)", tt.text, d.kind, d.specific, tt.kind, tt.specific)
+		}
+	}
+}
+
+func TestIsDirectiveComment(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"//go:generate stringer", true},
+		{"//docnametypo:ignore", true},
+		{"//line foo.go:10", true},
+		{"// note: not a directive", false},
+		{"//Deprecated: use Other", false},
+	}
+	for _, tt := range tests {
+		if got := isDirectiveComment(tt.text); got != tt.want {
+			t.Errorf("isDirectiveComment(%q)=%v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
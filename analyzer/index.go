@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// declIndex records the identifiers declared in a package, grouped by scope:
+// package-level declarations share the empty scope and methods are grouped by
+// receiver type name.
+type declIndex struct {
+	byScope map[string]map[string]*ast.Ident
+	scopeOf map[token.Pos]string
+	// order lists the declarations of each scope in source order.
+	order map[string][]*ast.Ident
+}
+
+// newDeclIndex collects the package-level declarations and methods of files.
+func newDeclIndex(files []*ast.File) declIndex {
+	idx := declIndex{
+		byScope: make(map[string]map[string]*ast.Ident),
+		scopeOf: make(map[token.Pos]string),
+		order:   make(map[string][]*ast.Ident),
+	}
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				scope := ""
+				if d.Recv != nil && len(d.Recv.List) > 0 {
+					recv := baseTypeIdent(d.Recv.List[0].Type)
+					if recv == nil {
+						continue
+					}
+					scope = recv.Name
+				}
+				idx.add(scope, d.Name)
+			case *ast.GenDecl:
+				if d.Tok == token.IMPORT {
+					continue
+				}
+				for _, spec := range d.Specs {
+					switch sp := spec.(type) {
+					case *ast.TypeSpec:
+						idx.add("", sp.Name)
+					case *ast.ValueSpec:
+						for _, name := range sp.Names {
+							idx.add("", name)
+						}
+					}
+				}
+			}
+		}
+	}
+	return idx
+}
+
+// add records id in scope, keeping the earliest declaration of each name.
+func (idx declIndex) add(scope string, id *ast.Ident) {
+	if id == nil || id.Name == "_" {
+		return
+	}
+	idx.scopeOf[id.Pos()] = scope
+	idx.order[scope] = append(idx.order[scope], id)
+	names := idx.byScope[scope]
+	if names == nil {
+		names = make(map[string]*ast.Ident)
+		idx.byScope[scope] = names
+	}
+	if prev, ok := names[id.Name]; ok && prev.Pos() <= id.Pos() {
+		return
+	}
+	names[id.Name] = id
+}
+
+// lookup returns the declaration named name that shares a scope with the
+// declaration at declPos. Declarations outside the index never match.
+func (idx declIndex) lookup(declPos token.Pos, name string) (*ast.Ident, bool) {
+	scope, ok := idx.scopeOf[declPos]
+	if !ok {
+		return nil, false
+	}
+	id, ok := idx.byScope[scope][name]
+	return id, ok
+}
+
+// ordinal returns the position of the declaration at declPos among the
+// declarations of its scope, counting from 0.
+func (idx declIndex) ordinal(declPos token.Pos) (int, bool) {
+	scope, ok := idx.scopeOf[declPos]
+	if !ok {
+		return 0, false
+	}
+	for i, id := range idx.order[scope] {
+		if id.Pos() == declPos {
+			return i, true
+		}
+	}
+	return 0, false
+}
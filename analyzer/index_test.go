@@ -0,0 +1,29 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestDeclIndexScopes(t *testing.T) {
+	fset := token.NewFileSet()
+	src := "package p\nfunc helper() {}\ntype T struct{}\nfunc (*T) helper() {}\nfunc (T) run() {}\n"
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx := newDeclIndex([]*ast.File{f})
+	run := f.Decls[3].(*ast.FuncDecl).Name
+	if got, ok := idx.lookup(run.Pos(), "helper"); !ok || got != f.Decls[2].(*ast.FuncDecl).Name {
+		t.Fatalf("expected method helper on T, got %v", got)
+	}
+	topLevel := f.Decls[0].(*ast.FuncDecl).Name
+	if got, ok := idx.lookup(topLevel.Pos(), "helper"); !ok || got != topLevel {
+		t.Fatalf("expected package-level helper, got %v", got)
+	}
+	if _, ok := idx.lookup(topLevel.Pos(), "run"); ok {
+		t.Fatalf("did not expect methods in the package scope")
+	}
+}
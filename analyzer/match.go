@@ -0,0 +1,273 @@
+package analyzer
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Names of the heuristics that can match a doc token to a symbol. They are
+// used as diagnostic categories.
+const (
+	ruleDistance          = "damerau-levenshtein"
+	ruleWeightedDistance  = "weighted-distance"
+	ruleCamelSwap         = "camel-swap"
+	ruleCaseMismatch      = "case-mismatch"
+	ruleInitialism        = "initialism"
+	ruleAbbreviation      = "abbreviation"
+	ruleSimilarCamelWord  = "similar-camel-word"
+	ruleCamelReplacement  = "camel-chunk-replacement"
+	ruleCamelInsertion    = "camel-chunk-insertion"
+	ruleSmallChunkDiff    = "small-chunk-difference"
+	ruleCopyPaste         = "copy-paste"
+	ruleStaleRename       = "stale-rename"
+	categoryStaleBaseline = "stale-baseline"
+	categoryUnusedIgnore  = "unused-ignore"
+	categoryBrokenDocLink = "broken-doc-link"
+	categoryTestName      = "test-name"
+)
+
+// Rule describes a diagnostic category reported by the analyzer.
+type Rule struct {
+	// ID is the value of analysis.Diagnostic.Category.
+	ID string
+	// Description summarizes what diagnostics in the category report.
+	Description string
+}
+
+// Rules returns the diagnostic categories the analyzer can report.
+func Rules() []Rule {
+	return []Rule{
+		{ruleDistance, "The doc comment's first word is within a small Damerau-Levenshtein distance of the symbol name."},
+		{ruleWeightedDistance, "The doc comment's first word is within a small weighted edit distance of the symbol name, with -distance=weighted."},
+		{ruleCamelSwap, "The doc comment's first word swaps two camelCase chunks of the symbol name."},
+		{ruleCaseMismatch, "The doc comment's first word differs from the symbol name only in case."},
+		{ruleInitialism, "The doc comment's first word differs from the symbol name only in the case of an initialism, such as userId for userID."},
+		{ruleAbbreviation, "The doc comment's first word differs from the symbol name only by a configured abbreviation, such as loadConfig for loadCfg."},
+		{ruleSimilarCamelWord, "The doc comment's first word differs from the symbol name by a typo in one camelCase chunk."},
+		{ruleCamelReplacement, "The doc comment's first word replaces camelCase chunks of the symbol name."},
+		{ruleCamelInsertion, "The doc comment's first word inserts or removes camelCase chunks of the symbol name."},
+		{ruleSmallChunkDiff, "The doc comment's first word inserts or removes a short run of characters from the symbol name."},
+		{ruleCopyPaste, "The doc comment's first word names a different declaration in the same package."},
+		{ruleStaleRename, "The doc comment's first word is the symbol's name before a rename recorded in git history."},
+		{categoryStaleBaseline, "A baseline entry no longer matches a finding."},
+		{categoryUnusedIgnore, "An ignore directive does not suppress any finding."},
+		{categoryBrokenDocLink, "A doc link such as [Name] or [pkg.Type.Method] does not resolve to a declaration."},
+		{categoryTestName, "An Example, Test, Benchmark or Fuzz function name refers to an identifier that is not declared but is close to one that is."},
+	}
+}
+
+// match records which heuristic tied a doc token to a symbol, along with the
+// measurements it was based on.
+type match struct {
+	rule string
+	// distance is the edit distance between the lowercased tokens, or
+	// between the differing chunks for similar-camel-word, under the
+	// configured metric.
+	distance     float64
+	sharedPrefix int
+	sharedSuffix int
+	// chunkMismatches counts camelCase chunks that differ, were swapped, or
+	// were inserted or removed.
+	chunkMismatches int
+	// lengthDiff is the number of runes inserted or removed for
+	// small-chunk-difference.
+	lengthDiff int
+	// confidence estimates from 0 to 1 how likely the doc token is a typo
+	// or stale form of the name, rounded to two decimals.
+	confidence float64
+}
+
+// Confidence of matches whose measurements do not vary with the tokens. A
+// case or spelling difference leaves no doubt about which name was meant; a
+// name copied from another declaration or found in git history is nearly as
+// certain.
+const (
+	caseConfidence         = 0.95
+	abbreviationConfidence = 0.9
+	camelSwapConfidence    = 0.9
+	copyPasteConfidence    = 0.9
+	staleRenameConfidence  = 0.95
+	// camelChunkConfidence caps the chunk replacement and insertion
+	// heuristics, since a changed word may be deliberate.
+	camelChunkConfidence = 0.8
+)
+
+// score computes the confidence of m for the tokens it matched. Edit-based
+// matches score by the share of the longer token left unchanged, and chunk
+// matches by the share of camelCase chunks kept.
+func (m match) score(docTok, name string) float64 {
+	longer := float64(max(utf8.RuneCountInString(docTok), utf8.RuneCountInString(name)))
+	chunks := float64(max(len(splitCamelWords(docTok)), len(splitCamelWords(name))))
+	var c float64
+	switch m.rule {
+	case ruleDistance, ruleWeightedDistance, ruleSimilarCamelWord:
+		c = 1 - m.distance/longer
+	case ruleSmallChunkDiff:
+		c = 1 - float64(m.lengthDiff)/longer
+	case ruleCaseMismatch, ruleInitialism:
+		c = caseConfidence
+	case ruleAbbreviation:
+		c = abbreviationConfidence
+	case ruleCamelSwap:
+		c = camelSwapConfidence
+	case ruleCamelReplacement, ruleCamelInsertion:
+		c = camelChunkConfidence * (1 - float64(m.chunkMismatches)/chunks)
+	}
+	return roundConfidence(c)
+}
+
+// roundConfidence clamps c to [0, 1] and rounds it to two decimals, the
+// precision diagnostics show, so that -min-confidence compares what users see.
+func roundConfidence(c float64) float64 {
+	return math.Round(min(max(c, 0), 1)*100) / 100
+}
+
+// String describes the rule and its measurements for -explain output.
+func (m match) String() string {
+	var parts []string
+	add := func(name string, v int) {
+		parts = append(parts, name+"="+strconv.Itoa(v))
+	}
+	addDistance := func(name string, v float64) {
+		parts = append(parts, name+"="+strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	switch m.rule {
+	case ruleDistance, ruleWeightedDistance:
+		addDistance("distance", m.distance)
+		add("shared-prefix", m.sharedPrefix)
+		add("shared-suffix", m.sharedSuffix)
+	case ruleSimilarCamelWord:
+		addDistance("chunk-distance", m.distance)
+		add("chunk-mismatches", m.chunkMismatches)
+	case ruleCamelSwap, ruleCamelReplacement, ruleCamelInsertion:
+		add("chunk-mismatches", m.chunkMismatches)
+	case ruleSmallChunkDiff:
+		add("length-diff", m.lengthDiff)
+	}
+	if len(parts) == 0 {
+		return m.rule
+	}
+	return m.rule + ": " + strings.Join(parts, ", ")
+}
+
+// matchDocToken runs the similarity heuristics in order and returns the first
+// one that considers docTok a typo or stale form of name, with its confidence.
+func matchDocToken(cfg matchConfig, docTok, name string) (match, bool) {
+	// Compare abbreviated chunks spelled out, so loadConfig documents
+	// loadCfg and a typo elsewhere in the token is still found.
+	if expDoc, expName := cfg.expandAbbreviations(docTok), cfg.expandAbbreviations(name); expDoc != docTok || expName != name {
+		if expDoc == expName {
+			if docTok == name || !cfg.ReportAbbreviations {
+				return match{}, false
+			}
+			return match{rule: ruleAbbreviation, confidence: abbreviationConfidence}, true
+		}
+		docTok, name = expDoc, expName
+	}
+	m, ok := matchHeuristics(cfg, docTok, name)
+	if ok {
+		m.confidence = m.score(docTok, name)
+	}
+	return m, ok
+}
+
+// matchHeuristics runs the heuristics of matchDocToken after abbreviations
+// are spelled out.
+func matchHeuristics(cfg matchConfig, docTok, name string) (match, bool) {
+	lenDiff := abs(utf8.RuneCountInString(docTok) - utf8.RuneCountInString(name))
+	docLower := strings.ToLower(docTok)
+	nameLower := strings.ToLower(name)
+	if lenDiff <= cfg.MaxDist+1 || lenDiff <= maxChunkDiffSize {
+		d := cfg.distance(docLower, nameLower)
+		if d > 0 && d <= float64(cfg.MaxDist) && passesDistanceGate(docLower, nameLower, d) {
+			return match{
+				rule:         cfg.distanceRule(),
+				distance:     d,
+				sharedPrefix: commonPrefixLength(docLower, nameLower),
+				sharedSuffix: commonSuffixLength(docLower, nameLower),
+			}, true
+		}
+	}
+
+	if isCamelSwapVariant(docTok, name) {
+		return match{rule: ruleCamelSwap, chunkMismatches: 2}, true
+	}
+	if isInitialismVariant(cfg, docTok, name) {
+		if !cfg.ReportInitialisms {
+			return match{}, false
+		}
+		return match{rule: ruleInitialism}, true
+	}
+	if strings.EqualFold(docTok, name) && docTok != name {
+		return match{rule: ruleCaseMismatch}, true
+	}
+	if hasSimilarCamelWord(cfg, docTok, name) {
+		m := match{rule: ruleSimilarCamelWord, chunkMismatches: 1}
+		docWords, symWords := splitCamelWords(docTok), splitCamelWords(name)
+		for i := range docWords {
+			if docWords[i] != symWords[i] {
+				m.distance = cfg.distance(docWords[i], symWords[i])
+				break
+			}
+		}
+		return m, true
+	}
+	if hasCamelChunkReplacement(docTok, name, cfg.MaxCamelChunkReplace) {
+		docWords, symWords := splitCamelWords(docTok), splitCamelWords(name)
+		m := match{rule: ruleCamelReplacement}
+		for i := range docWords {
+			if docWords[i] != symWords[i] {
+				m.chunkMismatches++
+			}
+		}
+		return m, true
+	}
+	if hasCamelChunkInsertionOrRemoval(docTok, name, cfg.MaxCamelChunkInsert) {
+		diff := abs(len(splitCamelWords(docTok)) - len(splitCamelWords(name)))
+		return match{rule: ruleCamelInsertion, chunkMismatches: diff}, true
+	}
+	if hasSmallChunkDifference(docLower, nameLower, maxChunkDiffSize) {
+		return match{rule: ruleSmallChunkDiff, lengthDiff: abs(utf8.RuneCountInString(docLower) - utf8.RuneCountInString(nameLower))}, true
+	}
+	return match{}, false
+}
+
+// isInitialismVariant reports whether docTok and name differ only in the case
+// of configured initialisms, as userId and userID do. A difference in the
+// case of the first letter changes whether the name is exported, so it is a
+// case mismatch instead.
+func isInitialismVariant(cfg matchConfig, docTok, name string) bool {
+	if docTok == name || !strings.EqualFold(docTok, name) || firstRune(docTok) != firstRune(name) {
+		return false
+	}
+	docChunks, nameChunks := splitCamelChunks(docTok), splitCamelChunks(name)
+	if len(docChunks) != len(nameChunks) {
+		return false
+	}
+	for i := range docChunks {
+		if docChunks[i] != nameChunks[i] && (!strings.EqualFold(docChunks[i], nameChunks[i]) || !cfg.isInitialism(docChunks[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// reason is the parenthesized explanation appended to diagnostics for m.
+func (m match) reason() string {
+	switch m.rule {
+	case ruleInitialism:
+		return "initialism case differs"
+	case ruleAbbreviation:
+		return "inconsistent abbreviation"
+	}
+	return "possible typo or old name"
+}
+
+// isSpelling reports whether m found two spellings of the same name rather
+// than a typo. Renaming the symbol to the doc token's spelling would trade
+// one inconsistency for another, so no rename is offered for these.
+func (m match) isSpelling() bool {
+	return m.rule == ruleInitialism || m.rule == ruleAbbreviation
+}
@@ -0,0 +1,62 @@
+package analyzer
+
+import "testing"
+
+func TestMatchDocToken(t *testing.T) {
+	cfg := newMatchConfig(DefaultConfig())
+	tests := []struct {
+		doc, sym string
+		want     match
+	}{
+		{"confgure", "configure", match{rule: ruleDistance, distance: 1, sharedPrefix: 4, sharedSuffix: 4, confidence: 0.89}},
+		{"getPodsReady", "getReadyPods", match{rule: ruleCamelSwap, chunkMismatches: 2, confidence: 0.9}},
+		{"findDBPathsById", "findDBPathsByID", match{rule: ruleInitialism, confidence: 0.95}},
+		{"HttpServer", "HTTPServer", match{rule: ruleInitialism, confidence: 0.95}},
+		{"parseUrls", "parseURLs", match{rule: ruleInitialism, confidence: 0.95}},
+		{"ParseConfig", "parseConfig", match{rule: ruleCaseMismatch, confidence: 0.95}},
+		{"parseconfig", "parseConfig", match{rule: ruleCaseMismatch, confidence: 0.95}},
+		{"newDbConn", "newDBConn", match{rule: ruleCaseMismatch, confidence: 0.95}},
+		{"newFilteredTelemetryHook", "NewTelemetryFilteredHook", match{rule: ruleCamelSwap, chunkMismatches: 2, confidence: 0.9}},
+		{"processCIDRs", "validateCIDRs", match{rule: ruleCamelReplacement, chunkMismatches: 1, confidence: 0.4}},
+		{"handleVolume", "handleEphemeralVolume", match{rule: ruleCamelInsertion, chunkMismatches: 1, confidence: 0.53}},
+		{"TelemetryHistoryState", "TelemetryHistory", match{rule: ruleDistance, distance: 5, sharedPrefix: 16, confidence: 0.76}},
+	}
+	for _, tt := range tests {
+		got, ok := matchDocToken(cfg, tt.doc, tt.sym)
+		if !ok || got != tt.want {
+			t.Errorf("matchDocToken(%q,%q)=%+v,%v, want %+v", tt.doc, tt.sym, got, ok, tt.want)
+		}
+	}
+
+	if got, ok := matchDocToken(cfg, "Returns", "parseConfig"); ok {
+		t.Errorf("matchDocToken(Returns, parseConfig)=%+v, want no match", got)
+	}
+
+	quiet := DefaultConfig()
+	quiet.ReportInitialisms = false
+	if got, ok := matchDocToken(newMatchConfig(quiet), "userId", "userID"); ok {
+		t.Errorf("matchDocToken(userId, userID) with ReportInitialisms off = %+v, want no match", got)
+	}
+	if got, ok := matchDocToken(cfg, "loadConfig", "loadCfg"); ok {
+		t.Errorf("matchDocToken(loadConfig, loadCfg)=%+v, want no match for an abbreviation", got)
+	}
+	if got, ok := matchDocToken(cfg, "handleReqMesage", "handleRequestMsg"); !ok || got.rule != ruleDistance {
+		t.Errorf("matchDocToken(handleReqMesage, handleRequestMsg)=%+v, %v, want a %s match after expanding abbreviations", got, ok, ruleDistance)
+	}
+	strict := DefaultConfig()
+	strict.ReportAbbreviations = true
+	for _, pair := range [][2]string{{"loadConfig", "loadCfg"}, {"newSrvCtx", "newServerContext"}, {"RespWriter", "ResponseWriter"}} {
+		if got, ok := matchDocToken(newMatchConfig(strict), pair[0], pair[1]); !ok || got.rule != ruleAbbreviation {
+			t.Errorf("matchDocToken(%s, %s) with ReportAbbreviations = %+v, %v, want %s", pair[0], pair[1], got, ok, ruleAbbreviation)
+		}
+	}
+	if got, ok := matchDocToken(newMatchConfig(strict), "newCtx", "newCtx"); ok {
+		t.Errorf("matchDocToken(newCtx, newCtx)=%+v, want no match", got)
+	}
+
+	custom := DefaultConfig()
+	custom.Initialisms = "DB"
+	if got, ok := matchDocToken(newMatchConfig(custom), "newDbConn", "newDBConn"); !ok || got.rule != ruleInitialism {
+		t.Errorf("matchDocToken(newDbConn, newDBConn) with DB initialism = %+v, %v, want %s", got, ok, ruleInitialism)
+	}
+}
@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"go/ast"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkQualifiedMethod checks a method doc comment that starts with a
+// receiver-qualified name such as T.Method or (*T).Method. The receiver and
+// the method name are compared separately, and each mismatch is reported
+// with its own fix. A receiver unrelated to the method's own receiver type
+// means the comment refers to something else, so nothing is reported.
+func (c *checker) checkQualifiedMethod(decl *ast.FuncDecl, q qualifiedName) {
+	name := decl.Name.Name
+	declPos := decl.Name.Pos()
+	tr := c.startTrace(name, kindFunc, declPos)
+	defer tr.flush()
+
+	if ast.IsExported(name) {
+		if !c.cfg.IncludeExported {
+			tr.step("skipped: exported declarations are not checked")
+			return
+		}
+	} else if !c.cfg.IncludeUnexported {
+		tr.step("skipped: unexported declarations are not checked")
+		return
+	}
+
+	recv := baseTypeIdent(decl.Recv.List[0].Type)
+	if recv == nil {
+		tr.step("skipped: receiver type has no name")
+		return
+	}
+	tr.step("doc receiver: %q, doc method: %q", q.recv, q.method)
+
+	if q.recv != recv.Name {
+		m, ok := matchDocToken(c.cfg, q.recv, recv.Name)
+		if !ok || utf8.RuneCountInString(q.recv) < minDocTokenLen {
+			tr.step("skipped: doc receiver does not name the receiver type")
+			return
+		}
+		tr.step("receiver matched by %s", m)
+		msg := "doc comment names receiver '" + q.recv + "' but method receiver is '" + recv.Name + "' (" + m.reason() + ")"
+		if c.cfg.Explain {
+			msg += " [" + m.String() + "]"
+		}
+		fixes := replaceTokenFixes(decl.Doc, q.recv, q.recvStart, q.recvEnd, recv.Name)
+		for i := range fixes {
+			fixes[i].Message = "replace doc receiver with receiver type name"
+		}
+		f := finding{doc: decl.Doc, name: recv.Name, kind: kindType, docTok: q.recv, tokStart: q.recvStart, tokEnd: q.recvEnd, declPos: recv.Pos(), trace: tr, confidence: m.confidence}
+		c.report(f, analysis.Diagnostic{
+			Pos:            recv.Pos(),
+			Category:       m.rule,
+			Message:        msg,
+			SuggestedFixes: fixes,
+		})
+	}
+
+	if q.method == name {
+		tr.step("not reported: doc method matches")
+		return
+	}
+	tokStart, tokEnd := q.methodStart, q.methodEnd
+	f := finding{doc: decl.Doc, name: name, kind: kindFunc, docTok: q.method, tokStart: tokStart, tokEnd: tokEnd, declPos: declPos, trace: tr}
+	if utf8.RuneCountInString(q.method) < minDocTokenLen {
+		tr.step("skipped: no doc token of at least %d characters", minDocTokenLen)
+		return
+	}
+	if c.cfg.matchesAllowedPrefixVariant(q.method, name) {
+		tr.step("skipped by matchesAllowedPrefixVariant")
+		return
+	}
+	if other, ok := c.decls.lookup(declPos, q.method); ok {
+		tr.step("matched by %s: doc method names the declaration at %s", ruleCopyPaste, c.pass.Fset.Position(other.Pos()))
+		c.reportOtherDecl(f, other)
+		return
+	}
+	m, ok := matchDocToken(c.cfg, q.method, name)
+	if !ok {
+		tr.step("not reported: no heuristic matched")
+		c.checkHistory(f)
+		return
+	}
+	tr.step("matched by %s", m)
+	fixes := replaceTokenFixes(decl.Doc, q.method, tokStart, tokEnd, name)
+	if !m.isSpelling() {
+		fixes = append(fixes, c.renameFix(name, q.method, declPos)...)
+	}
+	msg := "doc comment names method '" + q.method + "' but symbol is '" + name + "' (" + m.reason() + ")"
+	if c.cfg.Explain {
+		msg += " [" + m.String() + "]"
+	}
+	f.confidence = m.confidence
+	c.report(f, analysis.Diagnostic{
+		Pos:            declPos,
+		Category:       m.rule,
+		Message:        msg,
+		SuggestedFixes: fixes,
+	})
+}
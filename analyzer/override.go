@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Override changes settings for the packages matching any of its patterns.
+// Patterns use "..." to match any string and "*" to match within a path
+// element, so "example.com/m/internal/pb/..." matches that package and every
+// package below it.
+type Override struct {
+	// Packages lists import path patterns.
+	Packages []string `json:"packages,omitempty" yaml:"packages,omitempty"`
+	// Paths lists directory patterns, relative to the configuration file
+	// that declares them or, for settings given in code, to the working
+	// directory.
+	Paths []string `json:"paths,omitempty" yaml:"paths,omitempty"`
+
+	Settings `yaml:",inline"`
+
+	// dir is the directory relative patterns in Paths are resolved against.
+	dir string
+}
+
+// validate rejects override blocks that can never match or that nest
+// further overrides.
+func (o Override) validate() error {
+	if len(o.Packages) == 0 && len(o.Paths) == 0 {
+		return fmt.Errorf("override needs at least one of packages or paths")
+	}
+	if len(o.Overrides) > 0 {
+		return fmt.Errorf("overrides cannot be nested")
+	}
+	return nil
+}
+
+// matches reports whether the override applies to the package with the given
+// import path and directory.
+func (o Override) matches(pkgPath, dir string) bool {
+	for _, p := range o.Packages {
+		if matchPathPattern(p, pkgPath) || matchPathPattern(p, strings.TrimSuffix(pkgPath, "_test")) {
+			return true
+		}
+	}
+	if dir == "" {
+		return false
+	}
+	for _, p := range o.Paths {
+		if !filepath.IsAbs(p) {
+			base := o.dir
+			if base == "" {
+				base, _ = os.Getwd()
+			}
+			p = filepath.Join(base, p)
+		}
+		if matchPathPattern(filepath.ToSlash(p), filepath.ToSlash(dir)) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyOverrides layers the settings of each override matching the pass onto
+// conf, in declaration order.
+func applyOverrides(pass *analysis.Pass, conf Config) (Config, error) {
+	if len(conf.Overrides) == 0 {
+		return conf, nil
+	}
+	pkgPath := ""
+	if pass.Pkg != nil {
+		pkgPath = pass.Pkg.Path()
+	}
+	dir := packageDir(pass)
+	for i, o := range conf.Overrides {
+		if !o.matches(pkgPath, dir) {
+			continue
+		}
+		if err := o.Settings.Apply(&conf); err != nil {
+			return Config{}, fmt.Errorf("overrides[%d]: %w", i, err)
+		}
+	}
+	return conf, nil
+}
+
+// matchPathPattern reports whether a slash-separated path matches pattern.
+// A trailing "/..." also matches the path before it.
+func matchPathPattern(pattern, path string) bool {
+	if pattern == "" {
+		return false
+	}
+	var b strings.Builder
+	b.WriteString("^")
+	rest := pattern
+	for rest != "" {
+		switch {
+		case rest == "/...":
+			b.WriteString("(/.*)?")
+			rest = ""
+		case strings.HasPrefix(rest, "..."):
+			b.WriteString(".*")
+			rest = rest[3:]
+		case rest[0] == '*':
+			b.WriteString("[^/]*")
+			rest = rest[1:]
+		case rest[0] == '?':
+			b.WriteString("[^/]")
+			rest = rest[1:]
+		default:
+			b.WriteString(regexp.QuoteMeta(rest[:1]))
+			rest = rest[1:]
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	return err == nil && re.MatchString(path)
+}
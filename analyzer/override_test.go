@@ -0,0 +1,37 @@
+package analyzer
+
+import "testing"
+
+func TestMatchPathPattern(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"example.com/m/internal/pb/...", "example.com/m/internal/pb", true},
+		{"example.com/m/internal/pb/...", "example.com/m/internal/pb/v1", true},
+		{"example.com/m/internal/pb/...", "example.com/m/internal/pbx", false},
+		{"example.com/m/.../pb", "example.com/m/a/b/pb", true},
+		{"example.com/m/*/pb", "example.com/m/a/b/pb", false},
+		{"example.com/m/*/pb", "example.com/m/a/pb", true},
+		{"/repo/internal/gen?", "/repo/internal/gen2", true},
+		{"", "anything", false},
+	}
+	for _, tt := range tests {
+		if got := matchPathPattern(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchPathPattern(%q,%q)=%v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSettingsValidateOverrides(t *testing.T) {
+	if err := (Settings{Overrides: []Override{{}}}).Validate(); err == nil {
+		t.Fatalf("expected error for override without patterns")
+	}
+	nested := Override{Packages: []string{"a"}, Settings: Settings{Overrides: []Override{{Packages: []string{"b"}}}}}
+	if err := (Settings{Overrides: []Override{nested}}).Validate(); err == nil {
+		t.Fatalf("expected error for nested overrides")
+	}
+	if err := (Settings{Overrides: []Override{{Paths: []string{"internal/pb"}}}}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"path"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkPackageDoc compares the name in a "// Package name ..." file doc
+// comment with the package clause. Commands conventionally describe the
+// binary instead, as in "// Command name ...", so for package main the word
+// is compared, ignoring case, with the last element of the import path.
+// Other command docs start with ordinary prose and are not checked.
+func (c *checker) checkPackageDoc(file *ast.File) {
+	if file.Doc == nil || file.Name == nil || c.pass.Pkg == nil {
+		return
+	}
+	name := file.Name.Name
+	declPos := file.Name.Pos()
+	tr := c.startTrace(name, kindPackage, declPos)
+	defer tr.flush()
+
+	isMain := name == "main"
+	leads := []string{"Package"}
+	if isMain {
+		leads = append(leads, "Command")
+	}
+	docTok, tokStart, tokEnd := packageDocToken(file.Doc, leads)
+	tr.step("doc token: %q", docTok)
+	if docTok == "" {
+		tr.step("skipped: no package doc token")
+		return
+	}
+
+	want, got := name, docTok
+	switch {
+	case isMain && docTok != "main":
+		want = strings.ToLower(path.Base(c.pass.Pkg.Path()))
+		got = strings.ToLower(docTok)
+	case strings.HasSuffix(name, "_test") && !strings.HasSuffix(docTok, "_test"):
+		// External test packages may be documented as the package under test.
+		want = strings.TrimSuffix(name, "_test")
+	}
+	if got == want {
+		tr.step("not reported: doc token matches")
+		return
+	}
+	if utf8.RuneCountInString(docTok) < minDocTokenLen {
+		tr.step("skipped: no doc token of at least %d characters", minDocTokenLen)
+		return
+	}
+
+	m, ok := matchDocToken(c.cfg, got, want)
+	if !ok {
+		tr.step("not reported: no heuristic matched")
+		return
+	}
+	tr.step("matched by %s", m)
+
+	subject := "package is '" + name + "'"
+	if isMain {
+		subject = "command is '" + want + "'"
+	}
+	msg := "package doc comment names '" + docTok + "' but " + subject + " (possible typo or old name)"
+	if c.cfg.Explain {
+		msg += " [" + m.String() + "]"
+	}
+	f := finding{doc: file.Doc, name: name, kind: kindPackage, docTok: docTok, tokStart: tokStart, tokEnd: tokEnd, declPos: declPos, trace: tr, confidence: m.confidence}
+	c.report(f, analysis.Diagnostic{
+		Pos:            declPos,
+		Category:       m.rule,
+		Message:        msg,
+		SuggestedFixes: replaceTokenFixes(file.Doc, docTok, tokStart, tokEnd, want),
+	})
+}
+
+// packageDocToken returns the word naming the package in a package doc
+// comment: the word after one of leads.
+func packageDocToken(cg *ast.CommentGroup, leads []string) (tok string, start, end token.Pos) {
+	comment := firstDocComment(cg)
+	line, lineOffset := firstDocLine(comment.Text)
+	fields := strings.Fields(line)
+	if len(fields) < 2 || !slices.Contains(leads, fields[0]) {
+		return "", token.NoPos, token.NoPos
+	}
+	lead, word := fields[0], fields[1]
+	offset := len(lead) + strings.Index(line[len(lead):], word)
+	trimmed, left := trimWord(word)
+	if trimmed == "" {
+		return "", token.NoPos, token.NoPos
+	}
+	start = comment.Slash + token.Pos(lineOffset+offset+left)
+	return trimmed, start, start + token.Pos(len(trimmed))
+}
@@ -0,0 +1,161 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// renameScope holds what the checker needs to know about a package before it
+// can offer to rename one of its declarations. It is built on first use.
+type renameScope struct {
+	// defs maps declaring identifiers to their objects.
+	defs map[token.Pos]types.Object
+	// names holds every identifier name declared or used in the package.
+	names map[string]bool
+	// ifaceMethods holds the names of interface methods declared in the
+	// package, which concrete methods of the same name may implement.
+	ifaceMethods map[string]bool
+	// unseenTests reports that the package directory has in-package test
+	// files that are not part of this pass, so some uses may be missing.
+	unseenTests bool
+}
+
+// renameScope returns the pass's rename scope, or nil without type information.
+func (c *checker) renameScope() *renameScope {
+	if c.renames != nil {
+		return c.renames
+	}
+	if !c.hasTypes() {
+		return nil
+	}
+	info := c.pass.TypesInfo
+	s := &renameScope{
+		defs:         make(map[token.Pos]types.Object, len(info.Defs)),
+		names:        make(map[string]bool),
+		ifaceMethods: make(map[string]bool),
+	}
+	for id, obj := range info.Defs {
+		s.names[id.Name] = true
+		if obj == nil {
+			continue
+		}
+		s.defs[id.Pos()] = obj
+		if fn, ok := obj.(*types.Func); ok && isInterfaceMethod(fn) {
+			s.ifaceMethods[fn.Name()] = true
+		}
+	}
+	for id := range info.Uses {
+		s.names[id.Name] = true
+	}
+	for _, obj := range info.Implicits {
+		s.names[obj.Name()] = true
+	}
+	s.unseenTests = hasUnseenTestFiles(c.pass)
+	c.renames = s
+	return s
+}
+
+// renameFix renames the symbol declared at declPos, and every use of it, to
+// newName. It is offered only for unexported symbols whose uses are all in the
+// analyzed package and when newName is not already used anywhere in it.
+func (c *checker) renameFix(name, newName string, declPos token.Pos) []analysis.SuggestedFix {
+	if !renamable(name) || !renamable(newName) || types.Universe.Lookup(newName) != nil {
+		return nil
+	}
+	s := c.renameScope()
+	if s == nil || s.unseenTests || s.names[newName] {
+		return nil
+	}
+	obj := s.defs[declPos]
+	switch obj := obj.(type) {
+	case nil:
+		return nil
+	case *types.Var:
+		if obj.Embedded() {
+			return nil
+		}
+	case *types.Func:
+		if isInterfaceMethod(obj) || (obj.Signature().Recv() != nil && s.ifaceMethods[name]) {
+			return nil
+		}
+	}
+
+	edits := []analysis.TextEdit{{Pos: declPos, End: declPos + token.Pos(len(name)), NewText: []byte(newName)}}
+	for id, used := range c.pass.TypesInfo.Uses {
+		if originObject(used) == obj {
+			edits = append(edits, analysis.TextEdit{Pos: id.Pos(), End: id.End(), NewText: []byte(newName)})
+		}
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Pos < edits[j].Pos })
+	return []analysis.SuggestedFix{{
+		Message:   "rename '" + name + "' to '" + newName + "'",
+		TextEdits: edits,
+	}}
+}
+
+// renamable reports whether name is an unexported identifier that can be
+// renamed, or renamed to, without changing the package's behavior.
+func renamable(name string) bool {
+	switch name {
+	case "_", "init", "main":
+		return false
+	}
+	return token.IsIdentifier(name) && !ast.IsExported(name)
+}
+
+// isInterfaceMethod reports whether fn is declared in an interface type.
+func isInterfaceMethod(fn *types.Func) bool {
+	recv := fn.Signature().Recv()
+	return recv != nil && types.IsInterface(recv.Type())
+}
+
+// originObject maps a use in an instantiated generic type or function back to
+// the declared object.
+func originObject(obj types.Object) types.Object {
+	switch obj := obj.(type) {
+	case *types.Func:
+		return obj.Origin()
+	case *types.Var:
+		return obj.Origin()
+	}
+	return obj
+}
+
+// hasUnseenTestFiles reports whether the package directory holds _test.go
+// files of the same package that the pass does not include. Unexported
+// symbols may be used there, so renaming them would break the tests.
+func hasUnseenTestFiles(pass *analysis.Pass) bool {
+	dir := packageDir(pass)
+	if dir == "" {
+		return false
+	}
+	seen := make(map[string]bool, len(pass.Files))
+	for _, f := range pass.Files {
+		if tf := pass.Fset.File(f.Pos()); tf != nil {
+			seen[filepath.Base(tf.Name())] = true
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return true
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, "_test.go") || seen[name] {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.PackageClauseOnly)
+		if err != nil || f.Name.Name == pass.Pkg.Name() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package analyzer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRenameFix(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.IncludeFields = true
+	results := analysistest.Run(t, analysistest.TestData(), NewAnalyzer(cfg), "renamefix", "renametests")
+
+	// renames maps each reported doc token to the number of edits in its
+	// rename fix for each package variant, or -1 when none is offered.
+	renames := make(map[string][]int)
+	for _, r := range results {
+		for _, d := range r.Action.Diagnostics {
+			tok, _, _ := strings.Cut(strings.TrimPrefix(d.Message, "doc comment starts with '"), "'")
+			edits := -1
+			for _, fix := range d.SuggestedFixes[1:] {
+				edits = len(fix.TextEdits)
+			}
+			renames[tok] = append(renames[tok], edits)
+		}
+	}
+
+	for _, tt := range []struct {
+		tok   string
+		edits []int
+	}{
+		{"processRequest", []int{3}},
+		{"length", []int{3}},
+		{"appendItem", []int{2}},
+		// parseHeadr is already used as a local variable.
+		{"parseHeadr", []int{-1}},
+		// closeConn may implement the closer interface.
+		{"closConn", []int{-1}},
+		// The package without its tests cannot see every use; the test
+		// variant can.
+		{"loadConfig", []int{-1, 2}},
+	} {
+		got := renames[tt.tok]
+		if !slices.Equal(got, tt.edits) {
+			t.Errorf("%s: got rename edits %v, want %v", tt.tok, got, tt.edits)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+// Settings holds optional values for each analyzer flag. It is the schema of
+// .docnametypo.yaml files and of the golangci-lint plugin settings; fields
+// left nil keep their current value.
+type Settings struct {
+	MaxDist                 *int     `json:"maxdist,omitempty" yaml:"maxdist,omitempty"`
+	Distance                *string  `json:"distance,omitempty" yaml:"distance,omitempty"`
+	MinConfidence           *float64 `json:"min-confidence,omitempty" yaml:"min-confidence,omitempty"`
+	IncludeExported         *bool    `json:"include-exported,omitempty" yaml:"include-exported,omitempty"`
+	IncludeUnexported       *bool    `json:"include-unexported,omitempty" yaml:"include-unexported,omitempty"`
+	IncludeTypes            *bool    `json:"include-types,omitempty" yaml:"include-types,omitempty"`
+	IncludeGenerated        *bool    `json:"include-generated,omitempty" yaml:"include-generated,omitempty"`
+	IncludeInterfaceMethods *bool    `json:"include-interface-methods,omitempty" yaml:"include-interface-methods,omitempty"`
+	IncludeValues           *bool    `json:"include-values,omitempty" yaml:"include-values,omitempty"`
+	IncludeFields           *bool    `json:"include-fields,omitempty" yaml:"include-fields,omitempty"`
+	IncludePackageDoc       *bool    `json:"include-package-doc,omitempty" yaml:"include-package-doc,omitempty"`
+	CheckDocLinks           *bool    `json:"check-doc-links,omitempty" yaml:"check-doc-links,omitempty"`
+	CheckTestNames          *bool    `json:"check-test-names,omitempty" yaml:"check-test-names,omitempty"`
+	AllowedLeadingWords     *string  `json:"allowed-leading-words,omitempty" yaml:"allowed-leading-words,omitempty"`
+	AllowedPrefixes         *string  `json:"allowed-prefixes,omitempty" yaml:"allowed-prefixes,omitempty"`
+	Initialisms             *string  `json:"initialisms,omitempty" yaml:"initialisms,omitempty"`
+	ReportInitialisms       *bool    `json:"report-initialisms,omitempty" yaml:"report-initialisms,omitempty"`
+	Abbreviations           *string  `json:"abbreviations,omitempty" yaml:"abbreviations,omitempty"`
+	ReportAbbreviations     *bool    `json:"report-abbreviations,omitempty" yaml:"report-abbreviations,omitempty"`
+	SkipPlainWordCamel      *bool    `json:"skip-plain-word-camel,omitempty" yaml:"skip-plain-word-camel,omitempty"`
+	MaxCamelChunkInsert     *int     `json:"max-camel-chunk-insert,omitempty" yaml:"max-camel-chunk-insert,omitempty"`
+	MaxCamelChunkReplace    *int     `json:"max-camel-chunk-replace,omitempty" yaml:"max-camel-chunk-replace,omitempty"`
+	ReportUnusedIgnores     *bool    `json:"report-unused-ignores,omitempty" yaml:"report-unused-ignores,omitempty"`
+	Explain                 *bool    `json:"explain,omitempty" yaml:"explain,omitempty"`
+	ReportAtDeclaration     *bool    `json:"report-at-declaration,omitempty" yaml:"report-at-declaration,omitempty"`
+	GitHistory              *bool    `json:"git-history,omitempty" yaml:"git-history,omitempty"`
+	GitHistoryDepth         *int     `json:"git-history-depth,omitempty" yaml:"git-history-depth,omitempty"`
+
+	// Overrides change settings for matching packages. Later entries win.
+	Overrides []Override `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+}
+
+// Validate reports override blocks that can never match or that nest
+// further overrides.
+func (s Settings) Validate() error {
+	for i, o := range s.Overrides {
+		if err := o.validate(); err != nil {
+			return fmt.Errorf("overrides[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Apply overlays the values present in s onto cfg and appends its overrides.
+func (s Settings) Apply(cfg *Config) error {
+	fs := flag.NewFlagSet("docnametypo", flag.ContinueOnError)
+	registerFlags(fs, cfg)
+	if err := s.SetFlags(fs); err != nil {
+		return err
+	}
+	cfg.Overrides = append(cfg.Overrides, s.Overrides...)
+	return nil
+}
+
+// SetFlags sets the analyzer flag in fs for each value present in s, so that
+// the values take precedence over configuration files like explicit flags.
+// Overrides cannot be expressed as flags and are ignored.
+func (s Settings) SetFlags(fs *flag.FlagSet) error {
+	set := func(name, value string) error {
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("set %s: %w", name, err)
+		}
+		return nil
+	}
+	setInt := func(name string, v *int) error {
+		if v == nil {
+			return nil
+		}
+		return set(name, strconv.Itoa(*v))
+	}
+	setFloat := func(name string, v *float64) error {
+		if v == nil {
+			return nil
+		}
+		return set(name, strconv.FormatFloat(*v, 'g', -1, 64))
+	}
+	setBool := func(name string, v *bool) error {
+		if v == nil {
+			return nil
+		}
+		return set(name, strconv.FormatBool(*v))
+	}
+	setString := func(name string, v *string) error {
+		if v == nil {
+			return nil
+		}
+		return set(name, *v)
+	}
+
+	for _, err := range []error{
+		setInt("maxdist", s.MaxDist),
+		setString("distance", s.Distance),
+		setFloat("min-confidence", s.MinConfidence),
+		setBool("include-exported", s.IncludeExported),
+		setBool("include-unexported", s.IncludeUnexported),
+		setBool("include-types", s.IncludeTypes),
+		setBool("include-generated", s.IncludeGenerated),
+		setBool("include-interface-methods", s.IncludeInterfaceMethods),
+		setBool("include-values", s.IncludeValues),
+		setBool("include-fields", s.IncludeFields),
+		setBool("include-package-doc", s.IncludePackageDoc),
+		setBool("check-doc-links", s.CheckDocLinks),
+		setBool("check-test-names", s.CheckTestNames),
+		setString("allowed-leading-words", s.AllowedLeadingWords),
+		setString("allowed-prefixes", s.AllowedPrefixes),
+		setString("initialisms", s.Initialisms),
+		setBool("report-initialisms", s.ReportInitialisms),
+		setString("abbreviations", s.Abbreviations),
+		setBool("report-abbreviations", s.ReportAbbreviations),
+		setBool("skip-plain-word-camel", s.SkipPlainWordCamel),
+		setInt("max-camel-chunk-insert", s.MaxCamelChunkInsert),
+		setInt("max-camel-chunk-replace", s.MaxCamelChunkReplace),
+		setBool("report-unused-ignores", s.ReportUnusedIgnores),
+		setBool("explain", s.Explain),
+		setBool("report-at-declaration", s.ReportAtDeclaration),
+		setBool("git-history", s.GitHistory),
+		setInt("git-history-depth", s.GitHistoryDepth),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package abbreviations
+
+// loadConfig reads the configuration file.
+func loadCfg() {}
+
+// newServerContext derives a context for one request.
+func newSrvCtx() {}
+
+// writeResp sends the response.
+func writeResponse() {}
+
+// handleReqMesage decodes one request message. // want `doc comment starts with 'handleReqMesage' but symbol is 'handleRequestMsg' \(possible typo or old name\)`
+func handleRequestMsg() {}
@@ -0,0 +1,13 @@
+package abbreviationsreport
+
+// loadConfig reads the configuration file. // want `doc comment starts with 'loadConfig' but symbol is 'loadCfg' \(inconsistent abbreviation\)`
+func loadCfg() {}
+
+// newServerContext derives a context for one request. // want `doc comment starts with 'newServerContext' but symbol is 'newSrvCtx' \(inconsistent abbreviation\)`
+func newSrvCtx() {}
+
+// sendMsg sends a message. // want `doc comment starts with 'sendMsg' but symbol is 'sendMessage' \(inconsistent abbreviation\)`
+func sendMessage() {}
+
+// buildOptsTable lists the options, using an abbreviation added to the defaults. // want `doc comment starts with 'buildOptsTable' but symbol is 'buildOptionsTable' \(inconsistent abbreviation\)`
+func buildOptionsTable() {}
@@ -0,0 +1,10 @@
+package baseline // want `stale baseline entry: func 'loadSettings' no longer has a finding for doc token 'loadSetings' \(remove it from .*baseline.json\)` `stale baseline entry: func 'closeConnection' no longer has a finding for doc token 'closeConection' \(remove it from .*baseline.json\)`
+
+// parseConfg is a known finding recorded in the baseline.
+func parseConfig() {}
+
+// loadSettings was fixed after the baseline was written.
+func loadSettings() {}
+
+// flushBufer is a new finding that is not in the baseline. // want `doc comment starts with 'flushBufer' but symbol is 'flushBuffer' \(possible typo or old name\)`
+func flushBuffer() {}
@@ -0,0 +1,9 @@
+package baselinewrite
+
+// parseConfg is recorded instead of reported.
+func parseConfig() {}
+
+type client struct{}
+
+// dialRemote is recorded with its kind and doc token.
+func (client) dial() {}
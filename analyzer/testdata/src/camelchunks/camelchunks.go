@@ -1,13 +1,13 @@
 package camelchunks
 
-// processCIDRs returns CIDRs.
-func validateCIDRs() {} // want `doc comment starts with 'processCIDRs' but symbol is 'validateCIDRs' \(possible typo or old name\)`
+// processCIDRs returns CIDRs. // want `doc comment starts with 'processCIDRs' but symbol is 'validateCIDRs' \(possible typo or old name\)`
+func validateCIDRs() {}
 
-// handleVolume handles volume updates.
-func handleEphemeralVolume() {} // want `doc comment starts with 'handleVolume' but symbol is 'handleEphemeralVolume' \(possible typo or old name\)`
+// handleVolume handles volume updates. // want `doc comment starts with 'handleVolume' but symbol is 'handleEphemeralVolume' \(possible typo or old name\)`
+func handleEphemeralVolume() {}
 
-// syncHandler synchronizes the handler.
-func sync() {} // want `doc comment starts with 'syncHandler' but symbol is 'sync' \(possible typo or old name\)`
+// syncHandler synchronizes the handler. // want `doc comment starts with 'syncHandler' but symbol is 'sync' \(possible typo or old name\)`
+func sync() {}
 
 // option1 is a narrative sentence and should be ignored.
 func main() {}
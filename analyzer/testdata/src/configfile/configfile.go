@@ -0,0 +1,10 @@
+package configfile
+
+// defualtTimeout is checked because the config file enables values. // want `doc comment starts with 'defualtTimeout' but symbol is 'defaultTimeout' \(possible typo or old name\)`
+var defaultTimeout = 5
+
+// clinetState is checked because the config file enables types. // want `doc comment starts with 'clinetState' but symbol is 'clientState' \(possible typo or old name\)`
+type clientState struct{}
+
+// mimc is allowed through the configured op prefix.
+func opMimc() {}
@@ -0,0 +1,4 @@
+package nested
+
+// maxRetires inherits the settings of the parent directory's config file. // want `doc comment starts with 'maxRetires' but symbol is 'maxRetries' \(possible typo or old name\)`
+const maxRetries = 3
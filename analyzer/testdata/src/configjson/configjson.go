@@ -0,0 +1,7 @@
+package configjson
+
+// defualtTimeout is checked because the JSON config enables values. // want `doc comment starts with 'defualtTimeout' but symbol is 'defaultTimeout' \(possible typo or old name\)`
+var defaultTimeout = 5
+
+// clinetState is not checked because -include-types=false overrides the file.
+type clientState struct{}
@@ -0,0 +1,27 @@
+package copypaste
+
+// parseHeader reads the header block.
+func parseHeader() {}
+
+// parseHeader reads the footer block. // want `doc comment describes 'parseHeader', which is declared at copypaste.go:4, but symbol is 'parseFooter' \(possible copy-paste\)`
+func parseFooter() {}
+
+// maxHeaderSize limits how much is read.
+const maxHeaderSize = 1024
+
+// maxHeaderSize limits the footer. // want `doc comment describes 'maxHeaderSize', which is declared at copypaste.go:10, but symbol is 'readFooterLimit' \(possible copy-paste\)`
+func readFooterLimit() {}
+
+type conn struct{}
+
+// closeConn is a package-level helper.
+func closeConn() {}
+
+// closeConn releases the connection; methods only match their own receiver's methods. // want `doc comment starts with 'closeConn' but symbol is 'closeConnection' \(possible typo or old name\)`
+func (conn) closeConnection() {}
+
+// readLoop drives incoming frames.
+func (*conn) readLoop() {}
+
+// readLoop drives outgoing frames. // want `doc comment describes 'readLoop', which is declared at copypaste.go:24, but symbol is 'writeLoop' \(possible copy-paste\)`
+func (*conn) writeLoop() {}
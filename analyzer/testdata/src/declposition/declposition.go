@@ -0,0 +1,9 @@
+package declposition
+
+// parseHeadr reads the header block.
+func parseHeader() {} // want `doc comment starts with 'parseHeadr' but symbol is 'parseHeader' \(possible typo or old name\)`
+
+// readLop drives incoming frames.
+//
+// The legacy position is the declared name, not this comment.
+func readLoop() {} // want `doc comment starts with 'readLop' but symbol is 'readLoop' \(possible typo or old name\)`
@@ -0,0 +1,3 @@
+// Deploytol names the command as the first word of a sentence, which is not
+// checked.
+package main
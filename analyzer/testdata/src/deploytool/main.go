@@ -0,0 +1,4 @@
+// Command deploytol checks the command name after "Command". // want `package doc comment names 'deploytol' but command is 'deploytool' \(possible typo or old name\)`
+package main
+
+func main() {}
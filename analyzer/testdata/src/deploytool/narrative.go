@@ -0,0 +1,2 @@
+// This program's narrative package docs are left alone.
+package main
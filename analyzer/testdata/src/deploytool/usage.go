@@ -0,0 +1,3 @@
+// Serves HTTP requests for deployments. Command docs are only checked after
+// "Command" or "Package", so ordinary prose is left alone.
+package main
@@ -0,0 +1,10 @@
+package diffonly
+
+// parseHeadr was edited in the patch, so it is reported. // want `doc comment starts with 'parseHeadr' but symbol is 'parseHeader' \(possible typo or old name\)`
+func parseHeader() {}
+
+// readFootr predates the patch and is not reported.
+func readFooter() {}
+
+// writeBodyy is reported because its declaration line was renamed. // want `doc comment starts with 'writeBodyy' but symbol is 'writeBody' \(possible typo or old name\)`
+func writeBody() {}
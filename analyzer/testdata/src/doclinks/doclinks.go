@@ -0,0 +1,37 @@
+// Package doclinks documents its API with doc links such as [Config] and
+// [Confg]. // want `doc link \[Confg\] does not resolve; did you mean \[Config\]\?`
+package doclinks
+
+import (
+	"net/http"
+	str "strings"
+)
+
+// Config configures a [Server]. See [http.Handler], [net/http.Handler] and
+// [str.Builder] for the handler and the buffer it uses.
+type Config struct {
+	// Addr is passed to [http.ListenAndServe] unless [Config.Handlr] is set. // want `doc link \[Config.Handlr\] does not resolve; did you mean \[Config.Handler\]\?`
+	Addr string
+	// Handler serves requests, like [http.HandlerFunc].
+	Handler http.Handler
+}
+
+// Server serves requests. Stop it with [*Server.Close], not [Server.Clos]. // want `doc link \[Server.Clos\] does not resolve; did you mean \[Server.Close\]\?`
+type Server struct{}
+
+// Close stops the [Sever]. // want `doc link \[Sever\] does not resolve; did you mean \[Server\]\?`
+func (s *Server) Close() {}
+
+// NewServer returns a server for [Config]; see [Confg.Addr] and // want `doc link \[Confg.Addr\] does not resolve; did you mean \[Config.Addr\]\?`
+// [http.Handlr]. Unrelated links such as [Widget] are reported without a // want `doc link \[http.Handlr\] does not resolve; did you mean \[http.Handler\]\?` `doc link \[Widget\] does not resolve$`
+// fix, while [os.File], [Links] and words like slice[Index] or [a-b] are not
+// checked.
+//
+// Code blocks are left alone:
+//
+//	cfg := [Cnfig]{}
+//
+// [Links]: https://go.dev/doc/comment#links
+func NewServer(c Config) *Server { return nil }
+
+var _ str.Builder
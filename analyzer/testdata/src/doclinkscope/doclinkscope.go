@@ -0,0 +1,24 @@
+// Package doclinkscope checks doc links only in the comments of declarations
+// the -include-* settings select, so [Confg] here is not reported.
+package doclinkscope
+
+// Config is exported and not checked, so [Confg] is not reported.
+type Config struct {
+	// Addr links to [Confg] in a field comment, which is not checked.
+	Addr string
+}
+
+// handler links to [Confg] in an interface, which is not checked.
+type handler interface {
+	// serve links to [Confg] in an interface method, which is not checked.
+	serve()
+}
+
+// defaultAddr links to [Confg] in a var comment, which is not checked.
+var defaultAddr = ""
+
+// NewConfig is exported and not checked, so [Confg] is not reported.
+func NewConfig() Config { return Config{Addr: defaultAddr} }
+
+// newConfig is unexported and checked: [Confg] is reported. // want `doc link \[Confg\] does not resolve; did you mean \[Config\]\?`
+func newConfig() Config { return NewConfig() }
@@ -0,0 +1,19 @@
+package explain
+
+// confgure sets up the application. // want `doc comment starts with 'confgure' but symbol is 'configure' \(possible typo or old name\) \[damerau-levenshtein: distance=1, shared-prefix=4, shared-suffix=4\]`
+func configure() {}
+
+// getPodsReady lists ready pods. // want `doc comment starts with 'getPodsReady' but symbol is 'getReadyPods' \(possible typo or old name\) \[camel-swap: chunk-mismatches=2\]`
+func getReadyPods() {}
+
+// processCIDRs returns CIDRs. // want `doc comment starts with 'processCIDRs' but symbol is 'validateCIDRs' \(possible typo or old name\) \[camel-chunk-replacement: chunk-mismatches=1\]`
+func validateCIDRs() {}
+
+// handleVolume handles volume updates. // want `doc comment starts with 'handleVolume' but symbol is 'handleEphemeralVolume' \(possible typo or old name\) \[camel-chunk-insertion: chunk-mismatches=1\]`
+func handleEphemeralVolume() {}
+
+// parseHeader reads the header block.
+func parseHeader() {}
+
+// parseHeader reads the footer block. // want `doc comment describes 'parseHeader', which is declared at explain.go:16, but symbol is 'parseFooter' \(possible copy-paste\) \[copy-paste\]`
+func parseFooter() {}
@@ -0,0 +1,26 @@
+package fields
+
+type registry struct{}
+
+type client struct {
+	// retryCuont is the number of attempts before giving up. // want `doc comment starts with 'retryCuont' but symbol is 'retryCount' \(possible typo or old name\)`
+	retryCount int
+
+	timeoutMillis int // timeoutMilis bounds each attempt. // want `doc comment starts with 'timeoutMilis' but symbol is 'timeoutMillis' \(possible typo or old name\)`
+
+	// minBackof and maxBackoff bound the retry delay. // want `doc comment starts with 'minBackof' but symbol is 'minBackoff' \(possible typo or old name\)`
+	minBackoff, maxBackoff int
+
+	minRetry, maxRetry int // minRetry and maxRetry bound the attempts.
+
+	// registyr tracks known endpoints. // want `doc comment starts with 'registyr' but symbol is 'registry' \(possible typo or old name\)`
+	*registry
+
+	// endpoint is documented correctly.
+	endpoint string
+
+	options struct {
+		// verbos enables extra logging. // want `doc comment starts with 'verbos' but symbol is 'verbose' \(possible typo or old name\)`
+		verbose bool
+	}
+}
@@ -0,0 +1,22 @@
+package fixoccurrences
+
+// fetchRecrd loads one record. // want `doc comment starts with 'fetchRecrd' but symbol is 'fetchRecord' \(possible typo or old name\)`
+//
+// Call fetchRecrd once [fetchRecrd] has returned; see `fetchRecrd(id)`.
+// Longer names such as fetchRecrdAll, prefetchRecrd and store.fetchRecrd
+// are left alone.
+func fetchRecord() {}
+
+// want +3 `doc comment starts with 'storeRecrd' but symbol is 'storeRecord' \(possible typo or old name\)`
+
+/*
+storeRecrd saves one record.
+Retry storeRecrd on failure.
+*/
+func storeRecord() {}
+
+// copyPods copies the pods of a node.
+func copyPods() {}
+
+// copyPods is like copyPods but deletes the originals. // want `doc comment describes 'copyPods', which is declared at fixoccurrences.go:19, but symbol is 'movePods' \(possible copy-paste\)`
+func movePods() {}
@@ -3,5 +3,5 @@ package generatedcode
 
 type FooServer struct{}
 
-// ServeHTTP handles generated traffic.
-func (FooServer) ServeHTTPv1() {} // want `doc comment starts with 'ServeHTTP' but symbol is 'ServeHTTPv1' \(possible typo or old name\)`
+// ServeHTTP handles generated traffic. // want `doc comment starts with 'ServeHTTP' but symbol is 'ServeHTTPv1' \(possible typo or old name\)`
+func (FooServer) ServeHTTPv1() {}
@@ -0,0 +1,6 @@
+//docnametypo:file-ignore generated-style fixtures with historic names
+
+package ignores
+
+// newClinet builds a client.
+func newClient() {}
@@ -0,0 +1,34 @@
+package ignores
+
+// serveHtpp handles traffic; the directive below keeps the old name on purpose.
+//
+//docnametypo:ignore kept for grep compatibility with the v1 handler
+func serveHTTP() {}
+
+// decodePage updates cache entries.
+func encodePage() {} //docnametypo:ignore
+
+// parseConfg reads the configuration.
+func parseConfig() {} //nolint:docnametypo // legacy wording
+
+// loadSetings reads the settings.
+func loadSettings() {} //nolint:errcheck,docnametypo
+
+// flushBufer writes pending data.
+func flushBuffer() {} //nolint
+
+// closeConection releases the connection. // want `doc comment starts with 'closeConection' but symbol is 'closeConnection' \(possible typo or old name\)`
+func closeConnection() {} //nolint:errcheck
+
+//docnametypo:ignore
+// openConection dials the remote end and is silenced by the leading directive.
+func openConnection() {}
+
+// startServer is documented correctly.
+func startServer() {} //docnametypo:ignore // want `unused ignore directive '//docnametypo:ignore'`
+
+// stopServer is documented correctly.
+func stopServer() {} //nolint:docnametypo // want `unused ignore directive '//nolint:docnametypo'`
+
+// resetServer is documented correctly and bare nolint comments are never reported.
+func resetServer() {} //nolint:all
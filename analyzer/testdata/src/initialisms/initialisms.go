@@ -0,0 +1,16 @@
+package initialisms
+
+// userId returns the user's identifier. // want `doc comment starts with 'userId' but symbol is 'userID' \(initialism case differs\)`
+func userID() string { return "" }
+
+// parseUrls splits a list of addresses. // want `doc comment starts with 'parseUrls' but symbol is 'parseURLs' \(initialism case differs\)`
+func parseURLs() {}
+
+// newHttpServer builds a server. // want `doc comment starts with 'newHttpServer' but symbol is 'newHTTPServer' \(initialism case differs\)`
+func newHTTPServer() {}
+
+// newHTTPClient keeps a typo in a word that is not an initialism. // want `doc comment starts with 'newHTTPClient' but symbol is 'newHTTPClinet' \(possible typo or old name\)`
+func newHTTPClinet() {}
+
+// loadDbRows reads rows; DB is not in the default initialism list. // want `doc comment starts with 'loadDbRows' but symbol is 'loadDBRows' \(possible typo or old name\)`
+func loadDBRows() {}
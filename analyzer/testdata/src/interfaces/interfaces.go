@@ -2,6 +2,6 @@ package interfaces
 
 // handler shows interface doc drift.
 type handler interface {
-	// ServeHTTP handles requests on interface methods too.
-	serveHHTP() // want `doc comment starts with 'ServeHTTP' but symbol is 'serveHHTP' \(possible typo or old name\)`
+	// ServeHTTP handles requests on interface methods too. // want `doc comment starts with 'ServeHTTP' but symbol is 'serveHHTP' \(possible typo or old name\)`
+	serveHHTP()
 }
@@ -1,7 +1,7 @@
 package maxdistance
 
-// validateAllowedTopology ensures the topologies are valid.
-func validateAllowedTopologies() {} // want `doc comment starts with 'validateAllowedTopology' but symbol is 'validateAllowedTopologies' \(possible typo or old name\)`
+// validateAllowedTopology ensures the topologies are valid. // want `doc comment starts with 'validateAllowedTopology' but symbol is 'validateAllowedTopologies' \(possible typo or old name\)`
+func validateAllowedTopologies() {}
 
 // This program prints a message.
 func main() {}
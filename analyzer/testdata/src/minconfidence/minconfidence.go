@@ -0,0 +1,21 @@
+package minconfidence
+
+// confgure applies the settings. // want `doc comment starts with 'confgure' but symbol is 'configure' \(possible typo or old name\) \[.*\] \(confidence 0\.89\)`
+func configure() {}
+
+// getPodsReady lists the pods that are ready. // want `doc comment starts with 'getPodsReady' but symbol is 'getReadyPods' \(possible typo or old name\) \[.*\] \(confidence 0\.90\)`
+func getReadyPods() {}
+
+// newDbConn opens a connection. // want `doc comment starts with 'newDbConn' but symbol is 'newDBConn' \(possible typo or old name\) \[.*\] \(confidence 0\.95\)`
+func newDBConn() {}
+
+// processCIDRs replaces a whole word, so it scores below the threshold.
+func validateCIDRs() {}
+
+// handleVolume leaves out a word, which also scores below the threshold.
+func handleEphemeralVolume() {}
+
+// copyPods was copied from the declaration below. // want `doc comment describes 'copyPods', which is declared at minconfidence.go:21, but symbol is 'movePods' \(possible copy-paste\) \[copy-paste\] \(confidence 0\.90\)`
+func movePods() {}
+
+func copyPods() {}
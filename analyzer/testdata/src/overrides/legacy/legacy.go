@@ -0,0 +1,7 @@
+package legacy
+
+// defualtTimeout is not checked because the path override disables values.
+var defaultTimeout = 5
+
+// clinetState is checked because the path override enables types. // want `doc comment starts with 'clinetState' but symbol is 'clientState' \(possible typo or old name\)`
+type clientState struct{}
@@ -0,0 +1,7 @@
+package overrides
+
+// defualtTimeout is checked with the top-level settings. // want `doc comment starts with 'defualtTimeout' but symbol is 'defaultTimeout' \(possible typo or old name\)`
+var defaultTimeout = 5
+
+// clinetState is not checked because types are disabled here.
+type clientState struct{}
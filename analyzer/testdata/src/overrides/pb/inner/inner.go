@@ -0,0 +1,4 @@
+package inner
+
+// marshl is not checked because the override also covers subpackages.
+func marshal() {}
@@ -0,0 +1,4 @@
+// Package pkgdco provides helpers whose package doc has a typo. // want `package doc comment names 'pkgdco' but package is 'pkgdoc' \(possible typo or old name\)`
+//
+// See [pkgdco.Helper] for details.
+package pkgdoc
@@ -0,0 +1,2 @@
+// Package pkgdoc is how external test packages are often documented too.
+package pkgdoc_test
@@ -0,0 +1,5 @@
+// Package pkgdoc has a second, correct package doc.
+package pkgdoc
+
+// Helper does nothing.
+func Helper() {}
@@ -0,0 +1,2 @@
+// Package pkgdoc_test documents the external tests.
+package pkgdoc_test
@@ -1,7 +1,7 @@
 package plainwordcamelexpect
 
-// Delete removes stale devices from the system.
-func deleteDevice() {} // want `doc comment starts with 'Delete' but symbol is 'deleteDevice' \(possible typo or old name\)`
+// Delete removes stale devices from the system. // want `doc comment starts with 'Delete' but symbol is 'deleteDevice' \(possible typo or old name\)`
+func deleteDevice() {}
 
-// Add records the labels for telemetry.
-func addLabels() {} // want `doc comment starts with 'Add' but symbol is 'addLabels' \(possible typo or old name\)`
+// Add records the labels for telemetry. // want `doc comment starts with 'Add' but symbol is 'addLabels' \(possible typo or old name\)`
+func addLabels() {}
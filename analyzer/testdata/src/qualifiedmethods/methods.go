@@ -0,0 +1,31 @@
+package qualifiedmethods
+
+type server struct{}
+
+type client struct{}
+
+type cache[K comparable] struct{}
+
+// (*sever).shutdown stops the listener. // want `doc comment names receiver 'sever' but method receiver is 'server' \(possible typo or old name\)`
+func (s *server) shutdown() {}
+
+// server.stopListner() closes the socket. // want `doc comment names method 'stopListner' but symbol is 'stopListener' \(possible typo or old name\)`
+func (s *server) stopListener() {}
+
+// servr.handel, given a request, writes the reply. // want `doc comment names receiver 'servr' but method receiver is 'server'` `doc comment names method 'handel' but symbol is 'handle'`
+func (server) handle() {}
+
+// server.listen accepts connections.
+func (s *server) listen() {}
+
+// server.listen serves accepted connections. // want `doc comment describes 'listen', which is declared at methods.go:19, but symbol is 'serve' \(possible copy-paste\)`
+func (s *server) serve() {}
+
+// client.close mirrors how the client shuts down.
+func (s *server) close() {}
+
+// server.start begins accepting connections.
+func (s *server) start() {}
+
+// (*cache[K]).lookpu finds a cached entry. // want `doc comment names method 'lookpu' but symbol is 'lookup'`
+func (c *cache[K]) lookup() {}
@@ -0,0 +1,43 @@
+package renamefix
+
+// processRequest handles one request. // want `doc comment starts with 'processRequest' but symbol is 'procesRequest' \(possible typo or old name\)`
+func procesRequest(r string) string { return r }
+
+func handleAll() string {
+	return procesRequest("a") + procesRequest("b")
+}
+
+type list[T any] struct {
+	items []T
+	// length counts the stored items. // want `doc comment starts with 'length' but symbol is 'lenght' \(possible typo or old name\)`
+	lenght int
+}
+
+// appendItem stores v at the end of the list. // want `doc comment starts with 'appendItem' but symbol is 'apendItem' \(possible typo or old name\)`
+func (l *list[T]) apendItem(v T) {
+	l.items = append(l.items, v)
+	l.lenght++
+}
+
+func fill() list[int] {
+	l := list[int]{lenght: 0}
+	l.apendItem(1)
+	return l
+}
+
+// parseHeadr reads the header; the name is already taken below, so only the comment is fixed. // want `doc comment starts with 'parseHeadr' but symbol is 'parseHeader' \(possible typo or old name\)`
+func parseHeader() {
+	parseHeadr := 1
+	_ = parseHeadr
+}
+
+type closer interface {
+	closeConn()
+}
+
+type conn struct{}
+
+// closConn may satisfy closer, so renaming it is not offered. // want `doc comment starts with 'closConn' but symbol is 'closeConn' \(possible typo or old name\)`
+func (conn) closeConn() {}
+
+var _ closer = conn{}
@@ -0,0 +1,4 @@
+package renametests
+
+// loadConfig reads the configuration. // want `doc comment starts with 'loadConfig' but symbol is 'loadConfg' \(possible typo or old name\)`
+func loadConfg() string { return "" }
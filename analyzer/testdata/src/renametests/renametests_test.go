@@ -0,0 +1,3 @@
+package renametests
+
+var loaded = loadConfg()
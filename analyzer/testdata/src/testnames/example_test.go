@@ -0,0 +1,19 @@
+package testnames_test
+
+import "testnames"
+
+var _ testnames.Config
+
+func ExampleParseConfg() {} // want `ExampleParseConfg refers to 'ParseConfg', which is not declared; did you mean 'ParseConfig'\?`
+
+func ExampleServer_Clos_twice() {} // want `ExampleServer_Clos_twice refers to 'Server.Clos', which is not declared; did you mean 'Server.Close'\?`
+
+func ExampleConfig() {}
+
+func ExampleServer_Close() {}
+
+func ExampleServer_Close_twice() {}
+
+func ExampleConfg_second() {} // want `ExampleConfg_second refers to 'Confg', which is not declared; did you mean 'Config'\?`
+
+func Example_usage() {}
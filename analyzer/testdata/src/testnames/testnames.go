@@ -0,0 +1,30 @@
+package testnames
+
+// Config holds settings.
+type Config struct {
+	Addr string
+}
+
+// Parse parses a single setting.
+func Parse(s string) string { return s }
+
+// ParseConfig parses a configuration.
+func ParseConfig(s string) (Config, error) { return Config{Addr: s}, nil }
+
+// Server serves requests.
+type Server struct{}
+
+// Close stops the server.
+func (s *Server) Close() error { return nil }
+
+func (s *Server) handleConnect() {}
+
+func parseHeader() {}
+
+func decodeBody() {}
+
+type client struct{}
+
+func (c *client) sendRequest() {}
+
+func (c *client) close() {}
@@ -0,0 +1,49 @@
+package testnames
+
+import "testing"
+
+func TestParseConfg(t *testing.T) {} // want `TestParseConfg refers to 'ParseConfg', which is not declared; did you mean 'ParseConfig'\? \(possible typo or old name\)`
+
+func TestServer_handleConect(t *testing.T) {} // want `TestServer_handleConect refers to 'Server.handleConect', which is not declared; did you mean 'Server.handleConnect'\?`
+
+func Test_parseHeadr(t *testing.T) {} // want `Test_parseHeadr refers to 'parseHeadr', which is not declared; did you mean 'parseHeader'\?`
+
+func TestDecodeBdy(t *testing.T) {} // want `TestDecodeBdy refers to 'DecodeBdy', which is not declared; did you mean 'decodeBody'\?`
+
+func TestClient_SendRequst(t *testing.T) {} // want `TestClient_SendRequst refers to 'client.SendRequst', which is not declared; did you mean 'client.sendRequest'\?`
+
+func BenchmarkParseConfig(b *testing.B) {}
+
+// Tests named after the behavior they check are not reported.
+func TestParseConfigRejectsEmpty(t *testing.T) {}
+
+func TestServer_Close(t *testing.T) {}
+
+func TestServer_closeTwice(t *testing.T) {}
+
+func TestMain(m *testing.M) {}
+
+func Testhelper(t *testing.T) {}
+
+func TestUnrelatedBehavior(t *testing.T) {}
+
+// Tests named after something built on a declaration are not typos of it.
+func TestParser(t *testing.T) {}
+
+func TestParsed(t *testing.T) {}
+
+func TestConfigs(t *testing.T) {}
+
+func TestServer_Closed(t *testing.T) {}
+
+// Tests of unexported identifiers start with an upper case letter.
+func TestParseHeader(t *testing.T) {}
+
+func BenchmarkParseHeader(b *testing.B) {}
+
+func FuzzDecodeBody(f *testing.F) {}
+
+func TestClient_Close(t *testing.T) {}
+
+// helperConfig is not a test function.
+func helperConfig() {}
@@ -1,31 +1,31 @@
 package unexported
 
-// serveHtpp handles websocket traffic.
-func serveHTTP() {} // want `doc comment starts with 'serveHtpp' but symbol is 'serveHTTP' \(possible typo or old name\)`
+// serveHtpp handles websocket traffic. // want `doc comment starts with 'serveHtpp' but symbol is 'serveHTTP' \(possible typo or old name\)`
+func serveHTTP() {}
 
 type telemetryFilteredHook struct{}
 
-// newTelemetryFilterdHook creates the filtered hook.
-func newTelemetryFilteredHook() telemetryFilteredHook { // want `doc comment starts with 'newTelemetryFilterdHook' but symbol is 'newTelemetryFilteredHook' \(possible typo or old name\)`
+// newTelemetryFilterdHook creates the filtered hook. // want `doc comment starts with 'newTelemetryFilterdHook' but symbol is 'newTelemetryFilteredHook' \(possible typo or old name\)`
+func newTelemetryFilteredHook() telemetryFilteredHook {
 	return telemetryFilteredHook{}
 }
 
 type fooServer struct{}
 
-// serveHTTP handles HTTP requests.
-func (fooServer) serveHTTPv1() {} // want `doc comment starts with 'serveHTTP' but symbol is 'serveHTTPv1' \(possible typo or old name\)`
+// serveHTTP handles HTTP requests. // want `doc comment starts with 'serveHTTP' but symbol is 'serveHTTPv1' \(possible typo or old name\)`
+func (fooServer) serveHTTPv1() {}
 
-// decodePage updates cache entries.
-func encodePage() {} // want `doc comment starts with 'decodePage' but symbol is 'encodePage' \(possible typo or old name\)`
+// decodePage updates cache entries. // want `doc comment starts with 'decodePage' but symbol is 'encodePage' \(possible typo or old name\)`
+func encodePage() {}
 
 // Read reads everything but intentionally starts with a verb and should be treated as narrative.
 func readAll() {}
 
-// wsStreamHandler handles websocket streams.
-func wsStreamHandlerV1() {} // want `doc comment starts with 'wsStreamHandler' but symbol is 'wsStreamHandlerV1' \(possible typo or old name\)`
+// wsStreamHandler handles websocket streams. // want `doc comment starts with 'wsStreamHandler' but symbol is 'wsStreamHandlerV1' \(possible typo or old name\)`
+func wsStreamHandlerV1() {}
 
-// findDBPathsById locates DB paths.
-func findDBPathsByID() {} // want `doc comment starts with 'findDBPathsById' but symbol is 'findDBPathsByID' \(possible typo or old name\)`
+// findDBPathsById locates DB paths. // want `doc comment starts with 'findDBPathsById' but symbol is 'findDBPathsByID' \(initialism case differs\)`
+func findDBPathsByID() {}
 
 // generates numAccounts keys for reproducible fixtures. (narrative, no diagnostic expected)
 func generateKeys() {}
@@ -48,13 +48,15 @@ func valid() {}
 // reflect.DeepEqual does not work for topology.
 func topologyEqual() {}
 
-// ServeHTTP handles requests but the identifier is unexported so the analyzer should still flag it.
-func serveHHTP() {} // want `doc comment starts with 'ServeHTTP' but symbol is 'serveHHTP' \(possible typo or old name\)`
+// ServeHTTP handles requests but the identifier is unexported so the analyzer should still flag it. // want `doc comment starts with 'ServeHTTP' but symbol is 'serveHHTP' \(possible typo or old name\)`
+func serveHHTP() {}
+
+// want +3 `doc comment starts with 'ServeHTTPBlock' but symbol is 'serveHHTPBlock' \(possible typo or old name\)`
 
 /**
  * ServeHTTPBlock handles requests but block comments currently confuse the tokenizer.
  */
-func serveHHTPBlock() {} // want `doc comment starts with 'ServeHTTPBlock' but symbol is 'serveHHTPBlock' \(possible typo or old name\)`
+func serveHHTPBlock() {}
 
 type handler interface {
 	// ServeHTTP handles requests in interface declarations as well.
@@ -0,0 +1,19 @@
+package unicodeidents
+
+// größeBerechen computes the size of a parcel. // want `doc comment starts with 'größeBerechen' but symbol is 'größeBerechnen' \(possible typo or old name\)`
+func größeBerechnen() int { return 0 }
+
+// σύνολα returns the union of two sets. // want `doc comment starts with 'σύνολα' but symbol is 'σύνολο' \(possible typo or old name\)`
+func σύνολο() {}
+
+// straeName normalizes a street name. // want `doc comment starts with 'straeName' but symbol is 'straßeName' \(possible typo or old name\)`
+func straßeName() {}
+
+// 数据加载 loads the data.
+func 数据加载() {}
+
+// größeBerechnen2 is a second size helper; the trailing digit is part of the name.
+func größeBerechnen2() {}
+
+// café_открыть opens a café.
+func café_открыть() {}
@@ -0,0 +1,34 @@
+package values
+
+import "time"
+
+// defualtTimeout bounds each request. // want `doc comment starts with 'defualtTimeout' but symbol is 'defaultTimeout' \(possible typo or old name\)`
+var defaultTimeout = 5 * time.Second
+
+// maxRetires caps the retry loop. // want `doc comment starts with 'maxRetires' but symbol is 'maxRetries' \(possible typo or old name\)`
+const maxRetries = 3
+
+const (
+	// bufferSzie is the read buffer size. // want `doc comment starts with 'bufferSzie' but symbol is 'bufferSize' \(possible typo or old name\)`
+	bufferSize = 4096
+
+	// flushInterval is correct and should not be reported.
+	flushInterval = time.Second
+)
+
+// cacheEntires holds a single grouped spec documented on the declaration. // want `doc comment starts with 'cacheEntires' but symbol is 'cacheEntries' \(possible typo or old name\)`
+var (
+	cacheEntries = map[string]int{}
+)
+
+// Limits shared by both values; the group doc is not checked for multiple specs.
+var (
+	minWorkers = 1
+	maxWorkers = 8
+)
+
+// minIdle and maxIdle bound the idle pool; the doc may name either of them.
+var minIdle, maxIdle = 1, 4
+
+// The blank identifier is never checked.
+var _ = defaultTimeout
@@ -0,0 +1,13 @@
+package weighteddistance
+
+// synchrnze copies the local state to the peers. // want `doc comment starts with 'synchrnze' but symbol is 'synchronize' \(possible typo or old name\) \[weighted-distance: distance=1, shared-prefix=6, shared-suffix=2\]`
+func synchronize() {}
+
+// normlze lowercases and trims the key. // want `doc comment starts with 'normlze' but symbol is 'normalize' \(possible typo or old name\)`
+func normalize() {}
+
+// procesd handles a single queued item. // want `doc comment starts with 'procesd' but symbol is 'processs' \(possible typo or old name\)`
+func processs() {}
+
+// martial is not a slip of the keyboard for marshal.
+func marshal() {}
@@ -0,0 +1,308 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// testFuncPrefixes are the prefixes go test recognizes for top-level
+// functions in _test.go files.
+var testFuncPrefixes = []string{"Example", "Test", "Benchmark", "Fuzz"}
+
+// maxTestNameDistance bounds the edit distance of a test name from the
+// identifier it is close to, so that descriptive suffixes such as
+// TestServer_closeTwice do not count as typos of Close.
+const maxTestNameDistance = 2
+
+// testNamePart is an identifier named by a test function, such as Server
+// and handleConn in TestServer_handleConn, at offset at in the function name.
+type testNamePart struct {
+	name string
+	at   int
+}
+
+// checkTestName reports Example, Test, Benchmark and Fuzz functions whose
+// name refers to an identifier that the package under test does not declare
+// but that is close to one it does, following the go test conventions:
+// ExampleF, ExampleT_M and ExampleT_M_suffix for examples, and TestF,
+// Test_f and TestT_M for tests, benchmarks and fuzz targets.
+func (c *checker) checkTestName(decl *ast.FuncDecl) {
+	if decl.Recv != nil {
+		return
+	}
+	tf := c.pass.Fset.File(decl.Pos())
+	if tf == nil || !strings.HasSuffix(tf.Name(), "_test.go") {
+		return
+	}
+	fn := decl.Name.Name
+	var prefix string
+	for _, p := range testFuncPrefixes {
+		if strings.HasPrefix(fn, p) {
+			prefix = p
+			break
+		}
+	}
+	parts := splitTestName(fn, prefix)
+	if len(parts) == 0 {
+		return
+	}
+	scope := c.packageUnderTest()
+	if scope == nil {
+		return
+	}
+	tr := c.startTrace(fn, kindFunc, decl.Name.Pos())
+	defer tr.flush()
+
+	// Examples must name an exported identifier exactly. Tests are often
+	// named after what they check rather than a symbol, so for them only
+	// typo-like differences count, not added or replaced words. Tests must
+	// also start with an upper case letter, so TestParseHeader names
+	// parseHeader: the first rune of each part is compared ignoring case.
+	example := prefix == "Example"
+	lookup := func(name string, find func(string) types.Object) (types.Object, string) {
+		if obj := find(name); obj != nil || example {
+			return obj, name
+		}
+		other := swapFirstRuneCase(name)
+		return find(other), other
+	}
+	candidates := func(names []string, part string) ([]string, map[string]string) {
+		if example {
+			return exportedNames(names), nil
+		}
+		folded, originals := foldFirstRunes(names, part)
+		return withoutPrefixesOf(folded, part), originals
+	}
+	keep := func(m match) bool {
+		if example {
+			return true
+		}
+		switch m.rule {
+		case ruleDistance, ruleWeightedDistance:
+			return m.distance <= maxTestNameDistance
+		case ruleCamelSwap, ruleCaseMismatch, ruleSimilarCamelWord:
+			return true
+		}
+		return false
+	}
+
+	target := parts[0]
+	obj, declared := lookup(target.name, scope.Lookup)
+	if obj == nil {
+		tr.step("'%s' is not declared in the package under test", target.name)
+		names, originals := candidates(testTargetNames(scope), target.name)
+		fixed, m, ok := closestName(c.cfg, target.name, names, keep)
+		if !ok {
+			tr.step("not reported: no declaration is close to '%s'", target.name)
+			return
+		}
+		best := originalName(originals, fixed)
+		c.reportTestName(decl, target, best, fixed, "", m, scope.Lookup(best), tr)
+		return
+	}
+	tn, ok := obj.(*types.TypeName)
+	if !ok || len(parts) < 2 {
+		tr.step("not reported: '%s' is declared", declared)
+		return
+	}
+
+	target, qualifier := parts[1], tn.Name()+"."
+	findMember := func(name string) types.Object {
+		m, _, _ := types.LookupFieldOrMethod(tn.Type(), true, tn.Pkg(), name)
+		return m
+	}
+	if m, name := lookup(target.name, findMember); m != nil {
+		tr.step("not reported: '%s%s' is declared", qualifier, name)
+		return
+	}
+	tr.step("'%s%s' is not declared in the package under test", qualifier, target.name)
+	names, originals := candidates(typeMembers(tn.Type()), target.name)
+	fixed, m, ok := closestName(c.cfg, target.name, names, keep)
+	if !ok {
+		tr.step("not reported: no method or field is close to '%s'", target.name)
+		return
+	}
+	best := originalName(originals, fixed)
+	c.reportTestName(decl, target, best, fixed, qualifier, m, findMember(best), tr)
+}
+
+// reportTestName reports that part of the test function name should refer to
+// best, pointing at best's declaration when it is in the analyzed files. The
+// fix replaces part with fixed, which is best spelled as the name requires.
+func (c *checker) reportTestName(decl *ast.FuncDecl, part testNamePart, best, fixed, qualifier string, m match, obj types.Object, tr *declTrace) {
+	fn := decl.Name.Name
+	tr.step("matched by %s: '%s%s'", m, qualifier, best)
+	msg := fn + " refers to '" + qualifier + part.name + "', which is not declared; did you mean '" + qualifier + best + "'? (" + m.reason() + ")"
+	if c.cfg.Explain {
+		msg += " [" + m.String() + "]"
+	}
+	start := decl.Name.Pos() + token.Pos(part.at)
+	end := start + token.Pos(len(part.name))
+	d := analysis.Diagnostic{
+		Pos:      start,
+		End:      end,
+		Category: categoryTestName,
+		Message:  msg,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "rename " + fn + " to " + fn[:part.at] + fixed + fn[part.at+len(part.name):],
+			TextEdits: []analysis.TextEdit{{Pos: start, End: end, NewText: []byte(fixed)}},
+		}},
+	}
+	if obj != nil && obj.Pos().IsValid() && c.pass.Fset.File(obj.Pos()) != nil {
+		d.Related = []analysis.RelatedInformation{{
+			Pos:     obj.Pos(),
+			End:     obj.Pos() + token.Pos(len(best)),
+			Message: "'" + qualifier + best + "' is declared here",
+		}}
+	}
+	// The finding has no doc token, so report keeps the range set above.
+	c.report(finding{doc: decl.Doc, name: fn, kind: kindFunc, docTok: part.name, declPos: decl.Name.Pos(), trace: tr, confidence: m.confidence}, d)
+}
+
+// splitTestName returns the identifiers a test function name refers to: a
+// function or type, and for a type, possibly a method or field. It returns
+// nil for names go test does not treat as tests or that name nothing.
+func splitTestName(fn, prefix string) []testNamePart {
+	if prefix == "" || fn == "TestMain" {
+		return nil
+	}
+	rest := fn[len(prefix):]
+	if rest == "" {
+		return nil
+	}
+	at := len(prefix)
+	if prefix == "Example" {
+		if strings.HasPrefix(rest, "_") {
+			// Example_suffix documents the package.
+			return nil
+		}
+	} else if r, _ := utf8.DecodeRuneInString(rest); unicode.IsLower(r) {
+		// go test ignores Testxxx.
+		return nil
+	}
+	var parts []testNamePart
+	for i, elem := range strings.Split(rest, "_") {
+		switch {
+		case i == 0 && elem == "" && prefix != "Example":
+			// Test_f tests the unexported f.
+		case elem == "" || len(parts) == 2:
+		case prefix == "Example" && len(parts) > 0 && !startsUpper(elem):
+			// A lowercase element after the type or function is a suffix.
+			return parts
+		default:
+			parts = append(parts, testNamePart{name: elem, at: at})
+		}
+		at += len(elem) + 1
+	}
+	return parts
+}
+
+// startsUpper reports whether s starts with an upper case letter.
+func startsUpper(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsUpper(r)
+}
+
+// swapFirstRuneCase returns name with the case of its first rune changed.
+func swapFirstRuneCase(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	if unicode.IsUpper(r) {
+		r = unicode.ToLower(r)
+	} else {
+		r = unicode.ToUpper(r)
+	}
+	return string(r) + name[size:]
+}
+
+// foldFirstRunes returns names with their first rune in the case of part's,
+// so that a test name part is compared with the identifiers it may name
+// regardless of their export, and maps each folded name back to the original.
+func foldFirstRunes(names []string, part string) ([]string, map[string]string) {
+	upper := startsUpper(part)
+	folded := make([]string, 0, len(names))
+	originals := make(map[string]string, len(names))
+	for _, name := range names {
+		f := name
+		if startsUpper(name) != upper {
+			f = swapFirstRuneCase(name)
+		}
+		orig, seen := originals[f]
+		if !seen {
+			folded = append(folded, f)
+		}
+		// A name declared with part's case wins over a folded one.
+		if !seen || f == name && orig != name {
+			originals[f] = name
+		}
+	}
+	return folded, originals
+}
+
+// originalName maps a name folded by foldFirstRunes back to the declared one.
+func originalName(originals map[string]string, name string) string {
+	if orig, ok := originals[name]; ok {
+		return orig
+	}
+	return name
+}
+
+// withoutPrefixesOf returns names without those that part extends, such as
+// Parse for TestParser or Config for TestConfigs: the test is named after
+// something built on the declaration rather than a misspelling of it.
+func withoutPrefixesOf(names []string, part string) []string {
+	var kept []string
+	for _, name := range names {
+		if !strings.HasPrefix(part, name) {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
+// testTargetNames returns the names in scope that a test may be named after,
+// leaving out the test functions themselves.
+func testTargetNames(scope *types.Scope) []string {
+	var names []string
+	for _, name := range scope.Names() {
+		if _, ok := scope.Lookup(name).(*types.Func); ok && isTestFuncName(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// isTestFuncName reports whether name has one of the go test prefixes.
+func isTestFuncName(name string) bool {
+	for _, p := range testFuncPrefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// packageUnderTest returns the scope of the package the pass's tests
+// exercise: the package itself, or for an external _test package, the
+// package it imports under the same path without the suffix.
+func (c *checker) packageUnderTest() *types.Scope {
+	if c.pass.Pkg == nil {
+		return nil
+	}
+	path, external := strings.CutSuffix(c.pass.Pkg.Path(), "_test")
+	if !external {
+		return c.pass.Pkg.Scope()
+	}
+	for _, imp := range c.pass.Pkg.Imports() {
+		if imp.Path() == path {
+			return imp.Scope()
+		}
+	}
+	return nil
+}
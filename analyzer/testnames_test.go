@@ -0,0 +1,39 @@
+package analyzer
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitTestName(t *testing.T) {
+	tests := []struct {
+		fn, prefix string
+		want       []string
+	}{
+		{"TestParseConfig", "Test", []string{"ParseConfig"}},
+		{"TestServer_handleConn", "Test", []string{"Server", "handleConn"}},
+		{"TestServer_Close_twice", "Test", []string{"Server", "Close"}},
+		{"Test_parseHeader", "Test", []string{"parseHeader"}},
+		{"BenchmarkEncode", "Benchmark", []string{"Encode"}},
+		{"FuzzDecode", "Fuzz", []string{"Decode"}},
+		{"ExampleServer_Close", "Example", []string{"Server", "Close"}},
+		{"ExampleServer_Close_twice", "Example", []string{"Server", "Close"}},
+		{"ExampleParse_second", "Example", []string{"Parse"}},
+		{"Example_usage", "Example", nil},
+		{"Example", "Example", nil},
+		{"TestMain", "Test", nil},
+		{"Testhelper", "Test", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, p := range splitTestName(tt.fn, tt.prefix) {
+			if tt.fn[p.at:p.at+len(p.name)] != p.name {
+				t.Errorf("splitTestName(%q): part %q is not at offset %d", tt.fn, p.name, p.at)
+			}
+			got = append(got, p.name)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("splitTestName(%q) = %q, want %q", tt.fn, got, tt.want)
+		}
+	}
+}
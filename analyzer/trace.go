@@ -0,0 +1,46 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"sync"
+)
+
+// traceMu keeps the traces of concurrently analyzed packages from interleaving.
+var traceMu sync.Mutex
+
+// declTrace collects the decision steps for one declaration under -trace-symbols.
+// A nil *declTrace discards all steps.
+type declTrace struct {
+	c     *checker
+	lines []string
+}
+
+// startTrace begins a trace for the declaration if its name matches -trace-symbols.
+func (c *checker) startTrace(name string, kind symbolKind, declPos token.Pos) *declTrace {
+	if c.trace == nil || !c.trace.MatchString(name) {
+		return nil
+	}
+	t := &declTrace{c: c}
+	t.lines = append(t.lines, fmt.Sprintf("docnametypo trace: %s: %s %s", c.pass.Fset.Position(declPos), kind, name))
+	return t
+}
+
+// step records a decision step.
+func (t *declTrace) step(format string, args ...any) {
+	if t == nil {
+		return
+	}
+	t.lines = append(t.lines, "\t"+fmt.Sprintf(format, args...))
+}
+
+// flush writes the collected steps in a single block.
+func (t *declTrace) flush() {
+	if t == nil {
+		return
+	}
+	traceMu.Lock()
+	defer traceMu.Unlock()
+	fmt.Fprintln(t.c.traceOut, strings.Join(t.lines, "\n"))
+}
@@ -0,0 +1,33 @@
+package analyzer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestTrace(t *testing.T) {
+	var out bytes.Buffer
+	cfg := DefaultConfig()
+	cfg.Trace = "^(readAll|serveHTTP|notify)$"
+	cfg.TraceOutput = &out
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer(cfg), "unexported")
+
+	got := out.String()
+	for _, want := range []string{
+		"unexported.go:4:6: func serveHTTP\n\tdoc line: \"serveHtpp handles websocket traffic. // want",
+		"\tdoc token: \"serveHtpp\"\n\tdocFirstWordHasDot: no\n",
+		"\tmatched by damerau-levenshtein: distance=1, shared-prefix=7, shared-suffix=1\n\treported\n",
+		"func readAll\n\tdoc line: \"Read reads everything but intentionally starts with a verb and should be treated as narrative.\"\n\tdoc token: \"Read\"\n\tdocFirstWordHasDot: no\n\tisAllowedLeadingWord: yes\n\tskipped by isAllowedLeadingWord\n",
+		"func notify\n\tdoc line: \"note: helper for tests (label should be skipped)\"\n\tdoc token: \"helper\"\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("trace output missing %q; got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "wsStreamHandler") {
+		t.Errorf("trace output includes a declaration not matching -trace-symbols:\n%s", got)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+
+	"github.com/cce/docnametypo/analyzer"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// runJSON analyzes the packages named by args and writes the findings to
+// standard output in the JSON form of the -json driver flag, with the
+// confidence of each finding added. As with singlechecker, the exit code is
+// zero unless the analysis could not run.
+func runJSON(args []string) int {
+	graph, code := analyze(args, "-json")
+	if graph == nil {
+		return code
+	}
+	if err := writeJSON(os.Stdout, graph); err != nil {
+		fmt.Fprintln(os.Stderr, "docnametypo:", err)
+		return 1
+	}
+	if err := writeBaseline(graph); err != nil {
+		fmt.Fprintln(os.Stderr, "docnametypo:", err)
+		return 1
+	}
+	return code
+}
+
+// The types below follow the schema singlechecker uses for -json, a mapping
+// from package ID to analyzer name to a list of diagnostics or an error.
+
+type jsonError struct {
+	Err string `json:"error"`
+}
+
+type jsonDiagnostic struct {
+	Category       string             `json:"category,omitempty"`
+	Posn           string             `json:"posn"`
+	Message        string             `json:"message"`
+	Confidence     *float64           `json:"confidence,omitempty"`
+	SuggestedFixes []jsonSuggestedFix `json:"suggested_fixes,omitempty"`
+	Related        []jsonRelated      `json:"related,omitempty"`
+}
+
+type jsonSuggestedFix struct {
+	Message string         `json:"message"`
+	Edits   []jsonTextEdit `json:"edits"`
+}
+
+type jsonTextEdit struct {
+	Filename string `json:"filename"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	New      string `json:"new"`
+}
+
+type jsonRelated struct {
+	Posn    string `json:"posn"`
+	Message string `json:"message"`
+}
+
+// writeJSON writes the diagnostics of the root actions of graph, and the
+// errors of all its actions, to w.
+func writeJSON(w io.Writer, graph *checker.Graph) error {
+	tree := make(map[string]map[string]any)
+	add := func(act *checker.Action, v any) {
+		m, ok := tree[act.Package.ID]
+		if !ok {
+			m = make(map[string]any)
+			tree[act.Package.ID] = m
+		}
+		m[act.Analyzer.Name] = v
+	}
+	for act := range graph.All() {
+		if act.Err != nil {
+			add(act, jsonError{act.Err.Error()})
+			continue
+		}
+		if !act.IsRoot || len(act.Diagnostics) == 0 {
+			continue
+		}
+		result, _ := act.Result.(*analyzer.Result)
+		diags := make([]jsonDiagnostic, 0, len(act.Diagnostics))
+		for _, d := range act.Diagnostics {
+			diags = append(diags, toJSONDiagnostic(act.Package.Fset, d, result))
+		}
+		add(act, diags)
+	}
+	data, err := json.MarshalIndent(tree, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// toJSONDiagnostic converts d, taking its confidence from result.
+func toJSONDiagnostic(fset *token.FileSet, d analysis.Diagnostic, result *analyzer.Result) jsonDiagnostic {
+	jd := jsonDiagnostic{
+		Category: d.Category,
+		Posn:     fset.Position(d.Pos).String(),
+		Message:  d.Message,
+	}
+	if c, ok := result.Confidence(d); ok {
+		jd.Confidence = &c
+	}
+	for _, fix := range d.SuggestedFixes {
+		jf := jsonSuggestedFix{Message: fix.Message}
+		for _, e := range fix.TextEdits {
+			jf.Edits = append(jf.Edits, jsonTextEdit{
+				Filename: fset.Position(e.Pos).Filename,
+				Start:    fset.Position(e.Pos).Offset,
+				End:      fset.Position(e.End).Offset,
+				New:      string(e.NewText),
+			})
+		}
+		jd.SuggestedFixes = append(jd.SuggestedFixes, jf)
+	}
+	for _, r := range d.Related {
+		jd.Related = append(jd.Related, jsonRelated{Posn: fset.Position(r.Pos).String(), Message: r.Message})
+	}
+	return jd
+}
@@ -1,8 +1,221 @@
+// Command docnametypo runs the docnametypo analyzer.
+//
+// Besides the standard singlechecker flags, -format=sarif writes the findings
+// as a SARIF 2.1.0 log to standard output. With -json, each finding also
+// carries its confidence in a "confidence" field.
+//
+// With -format=sarif, -json or -baseline-write, the command runs the analyzer
+// itself rather than through singlechecker, and only -test of the driver
+// flags is supported; -fix, -diff, -c, -debug, -flags, -V and the profiling
+// flags are rejected.
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
 	"github.com/cce/docnametypo/analyzer"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
 	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/packages"
 )
 
-func main() { singlechecker.Main(analyzer.Analyzer) }
+func main() {
+	format, args, err := extractFormat(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "docnametypo:", err)
+		os.Exit(2)
+	}
+	switch format {
+	case "", "text":
+		if boolFlag(args, "json") {
+			os.Exit(runJSON(args))
+		}
+		if hasFlag(args, "baseline-write") {
+			// singlechecker exits before the baseline could be written.
+			os.Exit(runBaselineWrite(args))
+		}
+		os.Args = append(os.Args[:1], args...)
+		singlechecker.Main(analyzer.Analyzer)
+	case "sarif":
+		os.Exit(runSARIF(args))
+	default:
+		fmt.Fprintf(os.Stderr, "docnametypo: unknown -format %q (want text or sarif)\n", format)
+		os.Exit(2)
+	}
+}
+
+// extractFormat removes the -format flag from args, which singlechecker does
+// not know about, and returns its value.
+func extractFormat(args []string) (string, []string, error) {
+	format := ""
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "format" {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return "", nil, fmt.Errorf("flag needs an argument: -format")
+			}
+			i++
+			value = args[i]
+		}
+		format = value
+	}
+	return format, rest, nil
+}
+
+// hasFlag reports whether args, up to a "--", set the flag name.
+func hasFlag(args []string, name string) bool {
+	_, _, found := lookupFlag(args, name)
+	return found
+}
+
+// boolFlag reports whether args, up to a "--", set the boolean flag name to
+// true.
+func boolFlag(args []string, name string) bool {
+	value, hasValue, found := lookupFlag(args, name)
+	if !found || !hasValue {
+		return found
+	}
+	b, err := strconv.ParseBool(value)
+	return err == nil && b
+}
+
+// lookupFlag returns the value of the last -name=value in args up to a "--",
+// and whether the flag was given at all.
+func lookupFlag(args []string, name string) (value string, hasValue, found bool) {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		n, v, ok := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-"), "=")
+		if n == name {
+			value, hasValue, found = v, ok, true
+		}
+	}
+	return value, hasValue, found
+}
+
+// unsupportedDriverFlags are the singlechecker flags that analyze does not
+// implement, and whether each is a boolean flag.
+var unsupportedDriverFlags = map[string]bool{
+	"V": true, "c": false, "cpuprofile": false, "debug": false, "diff": true,
+	"fix": true, "flags": true, "memprofile": false, "trace": false,
+}
+
+// unsupportedFlag is a driver flag that fails to parse, so that using it
+// where analyze runs the analyzer is an error rather than silently ignored.
+type unsupportedFlag struct {
+	mode   string
+	isBool bool
+}
+
+func (f unsupportedFlag) String() string   { return "" }
+func (f unsupportedFlag) IsBoolFlag() bool { return f.isBool }
+func (f unsupportedFlag) Set(string) error {
+	return fmt.Errorf("not supported with %s", f.mode)
+}
+
+// analyze parses the analyzer flags in args, loads the packages they name
+// and runs the analyzer on them. mode is the flag that selected this path,
+// shown in -help output and in errors for unsupported driver flags. On
+// failure it returns a nil graph and the process exit code.
+func analyze(args []string, mode string) (*checker.Graph, int) {
+	fs := flag.NewFlagSet("docnametypo", flag.ExitOnError)
+	analyzer.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	tests := fs.Bool("test", true, "indicates whether test files should be analyzed, too")
+	fs.Bool("json", false, "emit JSON output")
+	for name, isBool := range unsupportedDriverFlags {
+		fs.Var(unsupportedFlag{mode: mode, isBool: isBool}, name, "not supported with "+mode)
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: docnametypo %s [flags] [packages]\n", mode)
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedImports |
+			packages.NeedTypes | packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedModule,
+		Tests: *tests,
+	}, fs.Args()...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "docnametypo:", err)
+		return nil, 1
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, 1
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{analyzer.Analyzer}, pkgs, nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "docnametypo:", err)
+		return nil, 1
+	}
+	return graph, 0
+}
+
+// runBaselineWrite analyzes the packages named by args and records their
+// findings in the -baseline-write file. Diagnostics that are not recorded,
+// such as unused ignore directives, are printed as singlechecker would. It
+// returns the process exit code.
+func runBaselineWrite(args []string) int {
+	graph, code := analyze(args, "-baseline-write")
+	if graph == nil {
+		return code
+	}
+	seen := make(map[string]bool)
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			fmt.Fprintf(os.Stderr, "docnametypo: %s: %v\n", act.Package.PkgPath, act.Err)
+			code = 1
+			continue
+		}
+		for _, d := range act.Diagnostics {
+			key := diagnosticKey(act.Package.Fset, d)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			fmt.Fprintf(os.Stderr, "%s: %s\n", act.Package.Fset.Position(d.Pos), d.Message)
+			if code == 0 {
+				code = 3
+			}
+		}
+	}
+	if err := writeBaseline(graph); err != nil {
+		fmt.Fprintln(os.Stderr, "docnametypo:", err)
+		return 1
+	}
+	return code
+}
+
+// writeBaseline writes the findings recorded by the analyzed packages to the
+// -baseline-write file, if one was given.
+func writeBaseline(graph *checker.Graph) error {
+	var results []*analyzer.Result
+	for _, act := range graph.Roots {
+		if r, ok := act.Result.(*analyzer.Result); ok {
+			results = append(results, r)
+		}
+	}
+	return analyzer.WriteBaseline(results)
+}
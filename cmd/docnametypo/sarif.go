@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/cce/docnametypo/analyzer"
+	"golang.org/x/tools/go/analysis"
+)
+
+// runSARIF analyzes the packages named by args and writes a SARIF log to
+// standard output. It returns the process exit code.
+func runSARIF(args []string) int {
+	graph, code := analyze(args, "-format=sarif")
+	if graph == nil {
+		return code
+	}
+
+	var (
+		fset        *token.FileSet
+		diags       []analysis.Diagnostic
+		confidences = make(map[string]float64)
+		seen        = make(map[string]bool)
+	)
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			fmt.Fprintf(os.Stderr, "docnametypo: %s: %v\n", act.Package.PkgPath, act.Err)
+			code = 1
+			continue
+		}
+		fset = act.Package.Fset
+		result, _ := act.Result.(*analyzer.Result)
+		for _, d := range act.Diagnostics {
+			// Test variants of a package repeat the diagnostics of its
+			// non-test files.
+			key := diagnosticKey(fset, d)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			diags = append(diags, d)
+			if c, ok := result.Confidence(d); ok {
+				confidences[key] = c
+			}
+		}
+	}
+
+	confidence := func(d analysis.Diagnostic) (float64, bool) {
+		c, ok := confidences[diagnosticKey(fset, d)]
+		return c, ok
+	}
+	wd, _ := os.Getwd()
+	if err := writeSARIF(os.Stdout, fset, diags, confidence, wd); err != nil {
+		fmt.Fprintln(os.Stderr, "docnametypo:", err)
+		return 1
+	}
+	if err := writeBaseline(graph); err != nil {
+		fmt.Fprintln(os.Stderr, "docnametypo:", err)
+		return 1
+	}
+	return code
+}
+
+// diagnosticKey identifies d across the test and non-test variants of a
+// package.
+func diagnosticKey(fset *token.FileSet, d analysis.Diagnostic) string {
+	return fset.Position(d.Pos).String() + "\x00" + d.Message
+}
+
+// The types below model the subset of SARIF 2.1.0 that docnametypo emits.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID           string          `json:"ruleId"`
+	RuleIndex        int             `json:"ruleIndex"`
+	Level            string          `json:"level"`
+	Rank             *float64        `json:"rank,omitempty"`
+	Message          sarifMessage    `json:"message"`
+	Locations        []sarifLocation `json:"locations"`
+	RelatedLocations []sarifLocation `json:"relatedLocations,omitempty"`
+	Fixes            []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               *int                  `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifMessage `json:"insertedContent"`
+}
+
+// writeSARIF encodes diags as a SARIF log with one rule per diagnostic
+// category, ranking results by their confidence when they have one. File
+// URIs are made relative to baseDir when possible.
+func writeSARIF(w io.Writer, fset *token.FileSet, diags []analysis.Diagnostic, confidence func(analysis.Diagnostic) (float64, bool), baseDir string) error {
+	rules := analyzer.Rules()
+	ruleIndex := make(map[string]int, len(rules))
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "docnametypo",
+			InformationURI: "https://github.com/cce/docnametypo",
+		}},
+		ColumnKind: "unicodeCodePoints",
+		Results:    []sarifResult{},
+	}
+	for i, r := range rules {
+		ruleIndex[r.ID] = i
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: r.ID, ShortDescription: sarifMessage{Text: r.Description}})
+	}
+
+	conv := &sarifConverter{fset: fset, baseDir: baseDir, confidence: confidence, lines: make(map[string][][]byte)}
+	for _, d := range diags {
+		idx, ok := ruleIndex[d.Category]
+		if !ok {
+			idx = len(run.Tool.Driver.Rules)
+			ruleIndex[d.Category] = idx
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: d.Category, ShortDescription: sarifMessage{Text: d.Category}})
+		}
+		run.Results = append(run.Results, conv.result(d, idx))
+	}
+
+	data, err := json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// sarifConverter maps token positions to SARIF locations.
+type sarifConverter struct {
+	fset    *token.FileSet
+	baseDir string
+	// confidence returns the confidence of a diagnostic, if it has one.
+	confidence func(analysis.Diagnostic) (float64, bool)
+	// lines caches file contents split into lines, for column conversion.
+	lines map[string][][]byte
+}
+
+// result converts a diagnostic. Its range, normally the doc token, is the
+// primary location and its related information, starting with the
+// declaration, becomes related locations.
+func (c *sarifConverter) result(d analysis.Diagnostic, ruleIndex int) sarifResult {
+	res := sarifResult{
+		RuleID:    d.Category,
+		RuleIndex: ruleIndex,
+		Level:     "warning",
+		Message:   sarifMessage{Text: d.Message},
+	}
+	// SARIF ranks results from 0 to 100.
+	if c, ok := c.confidence(d); ok {
+		rank := math.Round(c * 100)
+		res.Rank = &rank
+	}
+
+	res.Locations = []sarifLocation{{PhysicalLocation: c.location(d.Pos, d.End)}}
+	for i, r := range d.Related {
+		id := i + 1
+		res.RelatedLocations = append(res.RelatedLocations, sarifLocation{
+			ID:               &id,
+			PhysicalLocation: c.location(r.Pos, r.End),
+			Message:          &sarifMessage{Text: r.Message},
+		})
+	}
+
+	for _, fix := range d.SuggestedFixes {
+		sf := sarifFix{Description: sarifMessage{Text: fix.Message}}
+		byFile := make(map[string]int)
+		for _, edit := range fix.TextEdits {
+			loc := c.location(edit.Pos, edit.End)
+			i, ok := byFile[loc.ArtifactLocation.URI]
+			if !ok {
+				i = len(sf.ArtifactChanges)
+				byFile[loc.ArtifactLocation.URI] = i
+				sf.ArtifactChanges = append(sf.ArtifactChanges, sarifArtifactChange{ArtifactLocation: loc.ArtifactLocation})
+			}
+			sf.ArtifactChanges[i].Replacements = append(sf.ArtifactChanges[i].Replacements, sarifReplacement{
+				DeletedRegion:   loc.Region,
+				InsertedContent: sarifMessage{Text: string(edit.NewText)},
+			})
+		}
+		res.Fixes = append(res.Fixes, sf)
+	}
+	return res
+}
+
+// location converts a position range; an invalid end collapses to pos.
+func (c *sarifConverter) location(pos, end token.Pos) sarifPhysicalLocation {
+	if !end.IsValid() || end < pos {
+		end = pos
+	}
+	p := c.fset.Position(pos)
+	e := c.fset.Position(end)
+	return sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: c.uri(p.Filename)},
+		Region: sarifRegion{
+			StartLine:   p.Line,
+			StartColumn: c.column(p),
+			EndLine:     e.Line,
+			EndColumn:   c.column(e),
+		},
+	}
+}
+
+// uri returns filename relative to the base directory, or as a file URI.
+func (c *sarifConverter) uri(filename string) string {
+	if c.baseDir != "" {
+		if rel, err := filepath.Rel(c.baseDir, filename); err == nil && filepath.IsLocal(rel) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(filename)}).String()
+}
+
+// column converts the byte-based column of p to a code point column.
+func (c *sarifConverter) column(p token.Position) int {
+	lines, ok := c.lines[p.Filename]
+	if !ok {
+		if data, err := os.ReadFile(p.Filename); err == nil {
+			lines = bytes.SplitAfter(data, []byte("\n"))
+		}
+		c.lines[p.Filename] = lines
+	}
+	if p.Line < 1 || p.Line > len(lines) {
+		return p.Column
+	}
+	line := lines[p.Line-1]
+	n := min(max(p.Column-1, 0), len(line))
+	return utf8.RuneCount(line[:n]) + 1
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go/token"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// TestMain runs the command instead of the tests when the test binary is
+// started by runCommand.
+func TestMain(m *testing.M) {
+	if os.Getenv("DOCNAMETYPO_RUN_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runCommand runs docnametypo with args in dir and returns its standard
+// output and exit code.
+func runCommand(t *testing.T, dir string, args ...string) ([]byte, int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "DOCNAMETYPO_RUN_MAIN=1")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	var exit *exec.ExitError
+	if err != nil && !errors.As(err, &exit) {
+		t.Fatalf("run %v: %v", args, err)
+	}
+	t.Logf("docnametypo %v: %s", args, stderr.String())
+	return stdout.Bytes(), cmd.ProcessState.ExitCode()
+}
+
+// writeModule creates a module with a configuration file and one finding.
+func writeModule(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":            "module example.com/m\n\ngo 1.24\n",
+		".docnametypo.yaml": "maxdist: 3\n",
+		"p.go":              "package p\n\n// parseHeadr parses.\nfunc parseHeader() {}\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestFlagsOverrideConfigFile(t *testing.T) {
+	dir := writeModule(t)
+
+	if _, code := runCommand(t, dir, "./..."); code != 3 {
+		t.Fatalf("exit code without flags = %d, want 3", code)
+	}
+	if _, code := runCommand(t, dir, "-include-unexported=false", "./..."); code != 0 {
+		t.Errorf("text: exit code with -include-unexported=false = %d, want 0", code)
+	}
+
+	out, code := runCommand(t, dir, "-format=sarif", "-include-unexported=false", "./...")
+	var log sarifLog
+	if err := json.Unmarshal(out, &log); err != nil || code != 0 {
+		t.Fatalf("sarif: exit code %d, %v\n%s", code, err, out)
+	}
+	if n := len(log.Runs[0].Results); n != 0 {
+		t.Errorf("sarif: got %d results with -include-unexported=false, want 0", n)
+	}
+}
+
+func TestSARIFRank(t *testing.T) {
+	out, code := runCommand(t, writeModule(t), "-format=sarif", "./...")
+	var log sarifLog
+	if err := json.Unmarshal(out, &log); err != nil || code != 0 {
+		t.Fatalf("exit code %d, %v\n%s", code, err, out)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if rank := results[0].Rank; rank == nil || *rank != 91 {
+		t.Errorf("rank = %v, want 91", rank)
+	}
+}
+
+func TestBaselineWriteFlags(t *testing.T) {
+	dir := writeModule(t)
+	path := filepath.Join(dir, "baseline.json")
+	if _, code := runCommand(t, dir, "-baseline-write="+path, "-fix", "./..."); code != 2 {
+		t.Errorf("exit code with -baseline-write -fix = %d, want 2", code)
+	}
+	if _, code := runCommand(t, dir, "-baseline-write="+path, "-baseline="+path, "./..."); code != 1 {
+		t.Errorf("exit code with -baseline-write -baseline = %d, want 1", code)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("baseline written by a rejected run: %v", err)
+	}
+
+	out, code := runCommand(t, dir, "-baseline-write="+path, "-json", "./...")
+	if code != 0 || !json.Valid(out) {
+		t.Fatalf("-baseline-write -json: exit code %d, output %s", code, out)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("baseline not written with -json: %v", err)
+	}
+}
+
+func TestJSONConfidence(t *testing.T) {
+	dir := writeModule(t)
+	out, code := runCommand(t, dir, "-json", "./...")
+	if code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+	var tree map[string]map[string][]struct {
+		Message    string
+		Confidence *float64
+	}
+	if err := json.Unmarshal(out, &tree); err != nil {
+		t.Fatalf("unmarshal %s: %v", out, err)
+	}
+	diags := tree["example.com/m"]["docnametypo"]
+	if len(diags) != 1 || diags[0].Confidence == nil || *diags[0].Confidence <= 0 || *diags[0].Confidence > 1 {
+		t.Fatalf("diagnostics = %s, want one with a confidence in (0, 1]", out)
+	}
+	if strings.Contains(diags[0].Message, "confidence") {
+		t.Errorf("message %q carries the confidence without -explain", diags[0].Message)
+	}
+}
+
+func TestBaselineWrite(t *testing.T) {
+	dir := writeModule(t)
+	path := filepath.Join(dir, "baseline.json")
+	if _, code := runCommand(t, dir, "-baseline-write="+path, "./..."); code != 0 {
+		t.Fatalf("exit code with -baseline-write = %d, want 0", code)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bf struct{ Entries []json.RawMessage }
+	if err := json.Unmarshal(data, &bf); err != nil || len(bf.Entries) != 1 {
+		t.Fatalf("baseline = %s, %v; want one entry", data, err)
+	}
+	if _, code := runCommand(t, dir, "-baseline="+path, "./..."); code != 0 {
+		t.Errorf("exit code with -baseline = %d, want 0", code)
+	}
+}
+
+func TestExtractFormat(t *testing.T) {
+	tests := []struct {
+		args   []string
+		format string
+		rest   []string
+	}{
+		{[]string{"./..."}, "", []string{"./..."}},
+		{[]string{"-format=sarif", "./..."}, "sarif", []string{"./..."}},
+		{[]string{"--format", "text", "-fix", "./..."}, "text", []string{"-fix", "./..."}},
+		{[]string{"-maxdist=3", "-format", "sarif", "pkg"}, "sarif", []string{"-maxdist=3", "pkg"}},
+		{[]string{"--", "-format=sarif"}, "", []string{"--", "-format=sarif"}},
+	}
+	for _, tt := range tests {
+		format, rest, err := extractFormat(tt.args)
+		if err != nil {
+			t.Fatalf("extractFormat(%q): %v", tt.args, err)
+		}
+		if format != tt.format || !slices.Equal(rest, tt.rest) {
+			t.Errorf("extractFormat(%q) = %q, %q; want %q, %q", tt.args, format, rest, tt.format, tt.rest)
+		}
+	}
+	if _, _, err := extractFormat([]string{"-format"}); err == nil {
+		t.Errorf("expected error for -format without a value")
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	dir := t.TempDir()
+	src := "package p\n\n// résumé is wrong; parseHeadr parses.\nfunc parseHeader() {}\n"
+	filename := filepath.Join(dir, "p.go")
+	if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	file := fset.AddFile(filename, -1, len(src))
+	file.SetLinesForContent([]byte(src))
+	offset := func(s string) token.Pos {
+		return file.Pos(bytes.Index([]byte(src), []byte(s)))
+	}
+	tokStart := offset("parseHeadr")
+	tokEnd := tokStart + token.Pos(len("parseHeadr"))
+	decl := offset("func")
+
+	diag := analysis.Diagnostic{
+		Pos:      tokStart,
+		End:      tokEnd,
+		Category: "damerau-levenshtein",
+		Message:  "doc comment starts with 'parseHeadr' but symbol is 'parseHeader' (possible typo or old name)",
+		Related: []analysis.RelatedInformation{{
+			Pos:     decl + token.Pos(len("func ")),
+			End:     decl + token.Pos(len("func parseHeader")),
+			Message: "'parseHeader' is declared here",
+		}},
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message:   "replace doc token with symbol name",
+			TextEdits: []analysis.TextEdit{{Pos: tokStart, End: tokEnd, NewText: []byte("parseHeader")}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	confidence := func(analysis.Diagnostic) (float64, bool) { return 0.91, true }
+	if err := writeSARIF(&buf, fset, []analysis.Diagnostic{diag}, confidence, dir); err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log header: %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Results) != 1 {
+		t.Fatalf("got %d results, want 1", len(run.Results))
+	}
+	res := run.Results[0]
+	if got := run.Tool.Driver.Rules[res.RuleIndex].ID; got != res.RuleID || got != "damerau-levenshtein" {
+		t.Errorf("rule index %d names %q, result rule is %q", res.RuleIndex, got, res.RuleID)
+	}
+
+	if res.Rank == nil || *res.Rank != 91 {
+		t.Errorf("rank = %v, want 91", res.Rank)
+	}
+
+	// "// résumé is wrong; " is 20 code points but 22 bytes.
+	wantToken := sarifRegion{StartLine: 3, StartColumn: 21, EndLine: 3, EndColumn: 31}
+	primary := res.Locations[0].PhysicalLocation
+	if primary.ArtifactLocation.URI != "p.go" {
+		t.Errorf("primary URI = %q, want p.go", primary.ArtifactLocation.URI)
+	}
+	if primary.Region != wantToken {
+		t.Errorf("primary region = %+v, want %+v", primary.Region, wantToken)
+	}
+
+	if len(res.RelatedLocations) != 1 {
+		t.Fatalf("got %d related locations, want 1", len(res.RelatedLocations))
+	}
+	if got := res.RelatedLocations[0].PhysicalLocation.Region; got != (sarifRegion{StartLine: 4, StartColumn: 6, EndLine: 4, EndColumn: 17}) {
+		t.Errorf("declaration region = %+v, want line 4 columns 6-17", got)
+	}
+
+	if len(res.Fixes) != 1 || len(res.Fixes[0].ArtifactChanges) != 1 {
+		t.Fatalf("unexpected fixes: %+v", res.Fixes)
+	}
+	repl := res.Fixes[0].ArtifactChanges[0].Replacements[0]
+	if repl.DeletedRegion != wantToken || repl.InsertedContent.Text != "parseHeader" {
+		t.Errorf("replacement = %+v, want %+v -> parseHeader", repl, wantToken)
+	}
+}
@@ -0,0 +1,116 @@
+package gclplugin
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+const src = `package p
+
+// Config configures a [Server]; see [Confg].
+type Config struct{}
+
+// Server serves requests.
+type Server struct{}
+`
+
+func TestLoadMode(t *testing.T) {
+	p, err := New(map[string]any{"check-doc-links": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.GetLoadMode(); got != register.LoadModeTypesInfo {
+		t.Errorf("GetLoadMode() = %q, want %q", got, register.LoadModeTypesInfo)
+	}
+}
+
+// TestSyntaxOnlyPass runs the plugin analyzer the way golangci-lint does in
+// syntax mode, with an empty package scope and TypesInfo.
+func TestSyntaxOnlyPass(t *testing.T) {
+	p, err := New(map[string]any{"check-doc-links": true, "include-types": true, "include-exported": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyzers, err := p.BuildAnalyzers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	diags := runSyntaxOnly(t, analyzers[0])
+	if len(diags) != 0 {
+		t.Errorf("got diagnostics without type information: %v", diags)
+	}
+}
+
+// TestRenameFix checks that the plugin analyzer, given the type information
+// its load mode asks for, offers to rename the symbol as well as the doc.
+func TestRenameFix(t *testing.T) {
+	dir := t.TempDir()
+	pkg := filepath.Join(dir, "src", "p")
+	if err := os.MkdirAll(pkg, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	src := "package p\n\n// procesRequest handles a request. // want `procesRequest`\nfunc processRequest() {}\n\nvar _ = processRequest\n"
+	if err := os.WriteFile(filepath.Join(pkg, "p.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyzers, err := p.BuildAnalyzers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixes []string
+	for _, r := range analysistest.Run(t, dir, analyzers[0], "p") {
+		for _, d := range r.Action.Diagnostics {
+			for _, f := range d.SuggestedFixes {
+				fixes = append(fixes, f.Message)
+			}
+		}
+	}
+	if len(fixes) != 2 || !strings.HasPrefix(fixes[1], "rename 'processRequest'") {
+		t.Errorf("suggested fixes = %q, want the comment fix and a rename", fixes)
+	}
+}
+
+func runSyntaxOnly(t *testing.T, a *analysis.Analyzer) []analysis.Diagnostic {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Join(t.TempDir(), "p.go"), src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []*ast.File{f}
+	pass := &analysis.Pass{
+		Analyzer:  inspect.Analyzer,
+		Fset:      fset,
+		Files:     files,
+		Pkg:       types.NewPackage("example.com/p", "p"),
+		TypesInfo: &types.Info{},
+		ResultOf:  make(map[*analysis.Analyzer]any),
+	}
+	ins, err := inspect.Analyzer.Run(pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var diags []analysis.Diagnostic
+	pass.Analyzer = a
+	pass.ResultOf[inspect.Analyzer] = ins
+	pass.Report = func(d analysis.Diagnostic) { diags = append(diags, d) }
+	if _, err := a.Run(pass); err != nil {
+		t.Fatal(err)
+	}
+	return diags
+}
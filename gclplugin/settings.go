@@ -1,16 +1,7 @@
 package gclplugin
 
+import "github.com/cce/docnametypo/analyzer"
+
 // Settings control the docnametypo analyzer when loaded via golangci-lint's module plugin system.
-type Settings struct {
-	MaxDist                 *int    `json:"maxdist,omitempty"`
-	IncludeExported         *bool   `json:"include-exported,omitempty"`
-	IncludeUnexported       *bool   `json:"include-unexported,omitempty"`
-	IncludeTypes            *bool   `json:"include-types,omitempty"`
-	IncludeGenerated        *bool   `json:"include-generated,omitempty"`
-	IncludeInterfaceMethods *bool   `json:"include-interface-methods,omitempty"`
-	AllowedLeadingWords     *string `json:"allowed-leading-words,omitempty"`
-	AllowedPrefixes         *string `json:"allowed-prefixes,omitempty"`
-	SkipPlainWordCamel      *bool   `json:"skip-plain-word-camel,omitempty"`
-	MaxCamelChunkInsert     *int    `json:"max-camel-chunk-insert,omitempty"`
-	MaxCamelChunkReplace    *int    `json:"max-camel-chunk-replace,omitempty"`
-}
+// They use the same keys as .docnametypo.yaml files and take precedence over them.
+type Settings = analyzer.Settings
@@ -5,6 +5,7 @@ go 1.24.0
 require (
 	github.com/golangci/plugin-module-register v0.1.2
 	golang.org/x/tools v0.38.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (